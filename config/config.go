@@ -1,57 +1,221 @@
-// Package config provides configuration loading from environment variables
-// for the observability library. No hardcoded values; all settings come from env.
+// Package config provides configuration loading from environment variables and an optional
+// YAML/JSON file (OTEL_CONFIG_FILE) for the observability library. Env vars override the file,
+// and the file overrides the built-in defaults.
 package config
 
 import (
-	"os"
 	"strings"
 )
 
-// Config holds observability configuration loaded from environment variables.
+// Config holds observability configuration loaded from environment variables and OTEL_CONFIG_FILE.
+// Each field documents its env var; the equivalent file key is listed on File.
 type Config struct {
+	// Disabled turns the whole SDK off: observability.Init installs nothing, spans and metrics
+	// go to noop implementations and Logger keeps writing to stdout.
+	// Env: OTEL_SDK_DISABLED ("true" disables; default false)
+	Disabled bool
+
 	// ServiceName identifies the service in traces and logs (e.g., "order-service").
-	// Env: OTEL_SERVICE_NAME
+	// Env: OTEL_SERVICE_NAME, else service.name from ResourceAttributes (default "unknown-service")
 	ServiceName string
 
-	// ServiceVersion is the service version (optional). Env: OTEL_SERVICE_VERSION
+	// ServiceVersion is the service version (optional).
+	// Env: OTEL_SERVICE_VERSION, else service.version from ResourceAttributes
 	ServiceVersion string
 
 	// Environment is the deployment environment (e.g., "dev", "staging", "prod").
-	// Env: OTEL_ENVIRONMENT
+	// Env: OTEL_ENVIRONMENT, else deployment.environment from ResourceAttributes
+	// (default "development")
 	Environment string
 
-	// OtelEndpoint is the OTLP collector endpoint for trace/metric export (e.g., "localhost:4317" or "http://host:4317"; scheme is stripped for gRPC).
+	// OtelEndpoint is the OTLP collector endpoint shared by all signals, as configured
+	// (e.g., "localhost:4317", "http://host:4317" or "https://otel.example.com").
+	// Exporters use the resolved per-signal Traces/Metrics/Logs settings instead.
 	// Env: OTEL_EXPORTER_OTLP_ENDPOINT
 	OtelEndpoint string
+
+	// Protocol is the OTLP transport shared by all signals: "grpc" or "http/protobuf".
+	// Env: OTEL_EXPORTER_OTLP_PROTOCOL (default "grpc")
+	Protocol string
+
+	// Traces, Metrics and Logs are the resolved exporter settings per signal
+	// (Env: OTEL_EXPORTER_OTLP_{TRACES,METRICS,LOGS}_{ENDPOINT,PROTOCOL} override the shared values).
+	Traces  OTLP
+	Metrics OTLP
+	Logs    OTLP
+
+	// Sampler is the trace sampler: always_on, always_off, traceidratio, parentbased_always_on,
+	// parentbased_always_off or parentbased_traceidratio.
+	// Env: OTEL_TRACES_SAMPLER (default "parentbased_always_on")
+	Sampler string
+
+	// SamplerArg is the sampling ratio for the traceidratio samplers (0..1, default 1).
+	// Env: OTEL_TRACES_SAMPLER_ARG
+	SamplerArg string
+
+	// SamplingRules override the ratio for root spans of specific HTTP paths, gRPC methods
+	// or Kafka topics. HTTP patterns match the raw request path, not the route template.
+	// Env: OTEL_TRACES_SAMPLER_RULES (e.g. "http:/health*=0,kafka:audit=0.01")
+	SamplingRules []SamplingRule
+
+	// Propagators lists the trace context formats extracted from and injected into
+	// HTTP headers, gRPC metadata and Kafka headers: tracecontext, baggage, b3, b3multi,
+	// jaeger, xray or none. Env: OTEL_PROPAGATORS (default "tracecontext,baggage")
+	Propagators []string
+
+	// TracesExporter and MetricsExporter select where spans and metrics go: "otlp" (the Traces /
+	// Metrics endpoint), "console" (human-readable on stdout), "file" (JSON lines, see
+	// ExporterFileDir) or "none".
+	// Env: OTEL_TRACES_EXPORTER, OTEL_METRICS_EXPORTER (default "otlp")
+	TracesExporter  string
+	MetricsExporter string
+
+	// LogsExporter selects the log export pipeline: "otlp" sends log records to the Logs endpoint
+	// alongside stdout JSON, "console" prints them human-readable instead of stdout JSON, "file"
+	// appends them as JSON lines alongside stdout JSON, "none" keeps stdout JSON only.
+	// Env: OTEL_LOGS_EXPORTER (default "none")
+	LogsExporter string
+
+	// ExporterFileDir is the directory the "file" exporters write traces.jsonl, metrics.jsonl and
+	// logs.jsonl to. Env: OTEL_EXPORTER_FILE_DIR (default ".")
+	ExporterFileDir string
+
+	// LogLevel is the level used by logging.LoggerFromEnv: debug, info or error.
+	// Env: LOG_LEVEL (default "info")
+	LogLevel string
+
+	// ResourceAttributes are extra attributes added to the Resource shared by all signals
+	// (e.g., team or cluster). Env: OTEL_RESOURCE_ATTRIBUTES as "key1=value1,key2=value2" with
+	// URL-encoded values, merged key by key over the file's resource.attributes
+	ResourceAttributes map[string]string
+
+	// ResourceDetectors lists the detectors whose attributes observability.NewResource adds:
+	// host, process, os, container (ID from /proc/self/cgroup) and k8s (downward-API env vars).
+	// Env: OTEL_RESOURCE_DETECTORS (comma-separated; default none)
+	ResourceDetectors []string
+
+	// HTTPIgnorePaths and GrpcIgnoreMethods are URL path and gRPC full method prefixes that the
+	// HTTP middleware and gRPC server interceptors never trace, in addition to any WithIgnoredPaths /
+	// WithIgnoredMethods options. Env: OTEL_HTTP_IGNORE_PATHS, OTEL_GRPC_IGNORE_METHODS
+	// (comma-separated, e.g. "/health,/ready")
+	HTTPIgnorePaths   []string
+	GrpcIgnoreMethods []string
 }
 
-// Load reads configuration from environment variables.
-// Uses production-safe defaults when env vars are unset.
+// Load reads configuration from environment variables and, when OTEL_CONFIG_FILE is set, from
+// that file; env vars take precedence over file values. Uses production-safe defaults for unset
+// values and never fails: an unreadable or invalid file is ignored. Use LoadStrict to reject
+// invalid settings instead.
 func Load() *Config {
-	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	src, _ := newSource()
+	return load(src)
+}
+
+func load(src source) *Config {
+	resourceAttrs := make(map[string]string, len(src.resource))
+	for k, v := range src.resource {
+		resourceAttrs[k] = v
+	}
+	for k, v := range parseHeaders(src.get("OTEL_RESOURCE_ATTRIBUTES")) {
+		resourceAttrs[k] = v
+	}
+
+	// The dedicated variables win over the resource attributes only when they are set.
+	serviceName := src.get("OTEL_SERVICE_NAME")
 	if serviceName == "" {
-		serviceName = "unknown-service"
+		serviceName = resourceAttrs["service.name"]
+	}
+	if serviceName == "" {
+		serviceName = DefaultServiceName
 	}
 
-	serviceVersion := os.Getenv("OTEL_SERVICE_VERSION")
+	serviceVersion := src.get("OTEL_SERVICE_VERSION")
+	if serviceVersion == "" {
+		serviceVersion = resourceAttrs["service.version"]
+	}
 
-	env := os.Getenv("OTEL_ENVIRONMENT")
+	env := src.get("OTEL_ENVIRONMENT")
 	if env == "" {
-		env = "development"
+		env = resourceAttrs["deployment.environment"]
+	}
+	if env == "" {
+		env = EnvironmentDevelopment
+	}
+
+	protocol := strings.ToLower(strings.TrimSpace(src.get("OTEL_EXPORTER_OTLP_PROTOCOL")))
+	if protocol == "" {
+		protocol = ProtocolGRPC
 	}
 
-	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	// Scheme and path are kept: exporters derive TLS and the OTLP/HTTP URL from them.
+	rawEndpoint := src.get("OTEL_EXPORTER_OTLP_ENDPOINT")
+	endpoint := rawEndpoint
 	if endpoint == "" {
-		endpoint = "localhost:4317"
+		endpoint = defaultEndpoint(protocol)
+	}
+
+	sampler, samplerArg, samplingRules := loadSampler(src)
+
+	propagators := splitList(src.get("OTEL_PROPAGATORS"))
+	if len(propagators) == 0 {
+		propagators = []string{"tracecontext", "baggage"}
+	}
+
+	fileDir := src.get("OTEL_EXPORTER_FILE_DIR")
+	if fileDir == "" {
+		fileDir = "."
+	}
+
+	logLevel := strings.ToLower(strings.TrimSpace(src.get("LOG_LEVEL")))
+	if logLevel == "" {
+		logLevel = "info"
 	}
-	// gRPC dial expects host:port; strip http(s):// so "http://host:4317" works.
-	endpoint = strings.TrimPrefix(endpoint, "https://")
-	endpoint = strings.TrimPrefix(endpoint, "http://")
 
 	return &Config{
-		ServiceName:    serviceName,
-		ServiceVersion: serviceVersion,
-		Environment:     env,
-		OtelEndpoint:   endpoint,
+		Disabled:           strings.EqualFold(strings.TrimSpace(src.get("OTEL_SDK_DISABLED")), "true"),
+		ServiceName:        serviceName,
+		ServiceVersion:     serviceVersion,
+		Environment:        env,
+		OtelEndpoint:       endpoint,
+		Protocol:           protocol,
+		Traces:             loadOTLP(src, SignalTraces, rawEndpoint, protocol),
+		Metrics:            loadOTLP(src, SignalMetrics, rawEndpoint, protocol),
+		Logs:               loadOTLP(src, SignalLogs, rawEndpoint, protocol),
+		Sampler:            sampler,
+		SamplerArg:         samplerArg,
+		SamplingRules:      samplingRules,
+		Propagators:        propagators,
+		TracesExporter:     loadExporter(src, "OTEL_TRACES_EXPORTER", ExporterOTLP),
+		MetricsExporter:    loadExporter(src, "OTEL_METRICS_EXPORTER", ExporterOTLP),
+		LogsExporter:       loadExporter(src, "OTEL_LOGS_EXPORTER", ExporterNone),
+		ExporterFileDir:    fileDir,
+		LogLevel:           logLevel,
+		ResourceAttributes: resourceAttrs,
+		ResourceDetectors:  splitList(src.get("OTEL_RESOURCE_DETECTORS")),
+		HTTPIgnorePaths:    splitPrefixes(src.get("OTEL_HTTP_IGNORE_PATHS")),
+		GrpcIgnoreMethods:  splitPrefixes(src.get("OTEL_GRPC_IGNORE_METHODS")),
+	}
+}
+
+// splitList splits a comma-separated env value into lowercased, trimmed, non-empty items.
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitPrefixes splits a comma-separated list of paths or method names into trimmed, non-empty
+// items, keeping their case.
+func splitPrefixes(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
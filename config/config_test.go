@@ -0,0 +1,103 @@
+package config
+
+import (
+	"maps"
+	"testing"
+)
+
+// configEnv lists every variable Load reads, so tests start from a clean environment.
+var configEnv = []string{
+	"OTEL_CONFIG_FILE", "OTEL_SDK_DISABLED", "OTEL_SERVICE_NAME", "OTEL_SERVICE_VERSION", "OTEL_ENVIRONMENT",
+	"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_HEADERS",
+	"OTEL_EXPORTER_OTLP_COMPRESSION", "OTEL_EXPORTER_OTLP_INSECURE", "OTEL_EXPORTER_OTLP_CERTIFICATE",
+	"OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", "OTEL_EXPORTER_OTLP_CLIENT_KEY",
+	"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL", "OTEL_EXPORTER_OTLP_LOGS_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_TRACES_HEADERS", "OTEL_EXPORTER_OTLP_METRICS_HEADERS", "OTEL_EXPORTER_OTLP_LOGS_HEADERS",
+	"OTEL_TRACES_SAMPLER", "OTEL_TRACES_SAMPLER_ARG", "OTEL_TRACES_SAMPLER_RULES", "OTEL_PROPAGATORS",
+	"OTEL_TRACES_EXPORTER", "OTEL_METRICS_EXPORTER", "OTEL_LOGS_EXPORTER", "OTEL_EXPORTER_FILE_DIR",
+	"LOG_LEVEL", "OTEL_RESOURCE_ATTRIBUTES", "OTEL_RESOURCE_DETECTORS",
+	"OTEL_HTTP_IGNORE_PATHS", "OTEL_GRPC_IGNORE_METHODS",
+}
+
+// setEnv clears every variable Load reads, then sets env. Load treats empty values as unset.
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, k := range configEnv {
+		t.Setenv(k, "")
+	}
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+}
+
+func TestLoadResourceAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want map[string]string
+	}{
+		{name: "unset", raw: "", want: map[string]string{}},
+		{name: "pairs", raw: "team=lms,cluster=eu-west", want: map[string]string{"team": "lms", "cluster": "eu-west"}},
+		{name: "url-decoded values", raw: "note=hello%20world%2C%3Dx,path=%2Fapi", want: map[string]string{"note": "hello world,=x", "path": "/api"}},
+		{name: "invalid escape kept as is", raw: "bad=50%zz", want: map[string]string{"bad": "50%zz"}},
+		{name: "spaces trimmed", raw: " team = lms , tier=gold ", want: map[string]string{"team": "lms", "tier": "gold"}},
+		{name: "value with equals sign", raw: "expr=a=b", want: map[string]string{"expr": "a=b"}},
+		{name: "malformed pairs skipped", raw: "novalue,,=orphan,ok=1", want: map[string]string{"ok": "1"}},
+		{name: "last duplicate wins", raw: "team=a,team=b", want: map[string]string{"team": "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, map[string]string{"OTEL_RESOURCE_ATTRIBUTES": tt.raw})
+			if got := Load().ResourceAttributes; !maps.Equal(got, tt.want) {
+				t.Errorf("ResourceAttributes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadServiceIdentityPrecedence(t *testing.T) {
+	type identity struct{ name, version, env string }
+	tests := []struct {
+		name string
+		env  map[string]string
+		want identity
+	}{
+		{
+			name: "defaults",
+			want: identity{DefaultServiceName, "", EnvironmentDevelopment},
+		},
+		{
+			name: "resource attributes when the variables are unset",
+			env:  map[string]string{"OTEL_RESOURCE_ATTRIBUTES": "service.name=billing,service.version=2.0.1,deployment.environment=staging"},
+			want: identity{"billing", "2.0.1", EnvironmentStaging},
+		},
+		{
+			name: "variables win when set",
+			env: map[string]string{
+				"OTEL_RESOURCE_ATTRIBUTES": "service.name=billing,service.version=2.0.1,deployment.environment=staging",
+				"OTEL_SERVICE_NAME":        "orders",
+				"OTEL_SERVICE_VERSION":     "1.4.2",
+				"OTEL_ENVIRONMENT":         EnvironmentProduction,
+			},
+			want: identity{"orders", "1.4.2", EnvironmentProduction},
+		},
+		{
+			name: "each falls back independently",
+			env: map[string]string{
+				"OTEL_RESOURCE_ATTRIBUTES": "service.name=billing",
+				"OTEL_ENVIRONMENT":         EnvironmentTest,
+			},
+			want: identity{"billing", "", EnvironmentTest},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			cfg := Load()
+			if got := (identity{cfg.ServiceName, cfg.ServiceVersion, cfg.Environment}); got != tt.want {
+				t.Errorf("identity = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Exporter names. Env: OTEL_TRACES_EXPORTER / OTEL_METRICS_EXPORTER / OTEL_LOGS_EXPORTER
+const (
+	ExporterOTLP    = "otlp"    // OTLP to the Traces/Metrics/Logs endpoint
+	ExporterConsole = "console" // human-readable lines on stdout, for local development
+	ExporterFile    = "file"    // JSON lines appended to ExporterFilePath(signal)
+	ExporterNone    = "none"    // nothing is exported
+)
+
+// ExporterFilePath returns the JSON-lines file the "file" exporter of signal (SignalTraces,
+// SignalMetrics or SignalLogs) appends to, e.g. "<ExporterFileDir>/traces.jsonl".
+func (c *Config) ExporterFilePath(signal string) string {
+	return filepath.Join(c.ExporterFileDir, signal+".jsonl")
+}
+
+// loadExporter reads an OTEL_<SIGNAL>_EXPORTER setting, defaulting to def when unset.
+func loadExporter(src source, key, def string) string {
+	name := strings.ToLower(strings.TrimSpace(src.get(key)))
+	if name == "" {
+		return def
+	}
+	return name
+}
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is the OTEL_CONFIG_FILE format, YAML or JSON (JSON is valid YAML). Every field is
+// optional and equivalent to the env var named in its comment; a set env var always wins over
+// the file, and the file over the built-in default. An unreadable file or one with unknown keys
+// is ignored by Load and reported by LoadStrict.
+//
+//	disabled: false                        # OTEL_SDK_DISABLED
+//	service:
+//	  name: order-service                  # OTEL_SERVICE_NAME
+//	  version: 1.4.2                       # OTEL_SERVICE_VERSION
+//	  environment: production              # OTEL_ENVIRONMENT
+//	exporter:                              # OTEL_EXPORTER_OTLP_*, shared by all signals
+//	  endpoint: https://otel.example.com
+//	  protocol: http/protobuf
+//	  headers: {x-tenant: lms}
+//	  compression: gzip
+//	  file_dir: /var/log/otel              # OTEL_EXPORTER_FILE_DIR
+//	traces:
+//	  exporter: otlp                       # OTEL_TRACES_EXPORTER
+//	  sampler: parentbased_traceidratio    # OTEL_TRACES_SAMPLER
+//	  sampler_arg: 0.1                     # OTEL_TRACES_SAMPLER_ARG
+//	  sampling_rules: ["http:/health*=0"]  # OTEL_TRACES_SAMPLER_RULES
+//	metrics:
+//	  exporter: otlp                       # OTEL_METRICS_EXPORTER
+//	logs:
+//	  exporter: otlp                       # OTEL_LOGS_EXPORTER
+//	  level: info                          # LOG_LEVEL
+//	propagators: [tracecontext, baggage]   # OTEL_PROPAGATORS
+//	resource:
+//	  attributes: {team: lms}              # merged under OTEL_RESOURCE_ATTRIBUTES
+//	  detectors: [host, container, k8s]    # OTEL_RESOURCE_DETECTORS
+//	filters:
+//	  http_ignore_paths: [/health, /ready] # OTEL_HTTP_IGNORE_PATHS
+//	  grpc_ignore_methods: [/grpc.reflection.] # OTEL_GRPC_IGNORE_METHODS
+//
+// traces, metrics and logs also accept endpoint, protocol, headers, compression, insecure,
+// certificate, client_certificate and client_key, like exporter, for OTEL_EXPORTER_OTLP_<SIGNAL>_*.
+type File struct {
+	Disabled    *bool        `yaml:"disabled"`
+	Service     fileService  `yaml:"service"`
+	Exporter    fileExporter `yaml:"exporter"`
+	Traces      fileTraces   `yaml:"traces"`
+	Metrics     fileSignal   `yaml:"metrics"`
+	Logs        fileLogs     `yaml:"logs"`
+	Propagators []string     `yaml:"propagators"`
+	Resource    struct {
+		Attributes map[string]string `yaml:"attributes"`
+		Detectors  []string          `yaml:"detectors"`
+	} `yaml:"resource"`
+	Filters struct {
+		HTTPIgnorePaths   []string `yaml:"http_ignore_paths"`
+		GrpcIgnoreMethods []string `yaml:"grpc_ignore_methods"`
+	} `yaml:"filters"`
+}
+
+type fileService struct {
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Environment string `yaml:"environment"`
+}
+
+type fileOTLP struct {
+	Endpoint          string            `yaml:"endpoint"`
+	Protocol          string            `yaml:"protocol"`
+	Headers           map[string]string `yaml:"headers"`
+	Compression       string            `yaml:"compression"`
+	Insecure          *bool             `yaml:"insecure"`
+	Certificate       string            `yaml:"certificate"`
+	ClientCertificate string            `yaml:"client_certificate"`
+	ClientKey         string            `yaml:"client_key"`
+}
+
+type fileExporter struct {
+	fileOTLP `yaml:",inline"`
+	FileDir  string `yaml:"file_dir"`
+}
+
+type fileSignal struct {
+	Exporter string `yaml:"exporter"`
+	fileOTLP `yaml:",inline"`
+}
+
+type fileTraces struct {
+	fileSignal    `yaml:",inline"`
+	Sampler       string   `yaml:"sampler"`
+	SamplerArg    string   `yaml:"sampler_arg"`
+	SamplingRules []string `yaml:"sampling_rules"`
+}
+
+type fileLogs struct {
+	fileSignal `yaml:",inline"`
+	Level      string `yaml:"level"`
+}
+
+// readFile parses the file at path. Unknown keys are an error.
+func readFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("OTEL_CONFIG_FILE: %w", err)
+	}
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("OTEL_CONFIG_FILE %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// values returns the file settings keyed by the env var each one stands for.
+func (f *File) values() map[string]string {
+	v := map[string]string{}
+	set := func(key, value string) {
+		if value != "" {
+			v[key] = value
+		}
+	}
+	if f.Disabled != nil {
+		set("OTEL_SDK_DISABLED", strconv.FormatBool(*f.Disabled))
+	}
+	set("OTEL_SERVICE_NAME", f.Service.Name)
+	set("OTEL_SERVICE_VERSION", f.Service.Version)
+	set("OTEL_ENVIRONMENT", f.Service.Environment)
+
+	f.Exporter.fileOTLP.setValues(set, "OTEL_EXPORTER_OTLP_")
+	set("OTEL_EXPORTER_FILE_DIR", f.Exporter.FileDir)
+
+	for _, s := range []struct {
+		signal string
+		fs     fileSignal
+	}{{SignalTraces, f.Traces.fileSignal}, {SignalMetrics, f.Metrics}, {SignalLogs, f.Logs.fileSignal}} {
+		set("OTEL_"+strings.ToUpper(s.signal)+"_EXPORTER", s.fs.Exporter)
+		s.fs.fileOTLP.setValues(set, "OTEL_EXPORTER_OTLP_"+strings.ToUpper(s.signal)+"_")
+	}
+
+	set("OTEL_TRACES_SAMPLER", f.Traces.Sampler)
+	set("OTEL_TRACES_SAMPLER_ARG", f.Traces.SamplerArg)
+	set("OTEL_TRACES_SAMPLER_RULES", strings.Join(f.Traces.SamplingRules, ","))
+	set("LOG_LEVEL", f.Logs.Level)
+	set("OTEL_PROPAGATORS", strings.Join(f.Propagators, ","))
+	set("OTEL_RESOURCE_DETECTORS", strings.Join(f.Resource.Detectors, ","))
+	set("OTEL_HTTP_IGNORE_PATHS", strings.Join(f.Filters.HTTPIgnorePaths, ","))
+	set("OTEL_GRPC_IGNORE_METHODS", strings.Join(f.Filters.GrpcIgnoreMethods, ","))
+	return v
+}
+
+func (o fileOTLP) setValues(set func(key, value string), prefix string) {
+	set(prefix+"ENDPOINT", o.Endpoint)
+	set(prefix+"PROTOCOL", o.Protocol)
+	set(prefix+"COMPRESSION", o.Compression)
+	set(prefix+"CERTIFICATE", o.Certificate)
+	set(prefix+"CLIENT_CERTIFICATE", o.ClientCertificate)
+	set(prefix+"CLIENT_KEY", o.ClientKey)
+	if o.Insecure != nil {
+		set(prefix+"INSECURE", strconv.FormatBool(*o.Insecure))
+	}
+	if len(o.Headers) > 0 {
+		keys := make([]string, 0, len(o.Headers))
+		for k := range o.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+url.PathEscape(o.Headers[k]))
+		}
+		set(prefix+"HEADERS", strings.Join(pairs, ","))
+	}
+}
+
+// source looks settings up by env var name: the environment first, then OTEL_CONFIG_FILE.
+type source struct {
+	file     map[string]string
+	resource map[string]string
+}
+
+// newSource reads OTEL_CONFIG_FILE when set. On error the returned source is env-only.
+func newSource() (source, error) {
+	path := os.Getenv("OTEL_CONFIG_FILE")
+	if path == "" {
+		return source{}, nil
+	}
+	f, err := readFile(path)
+	if err != nil {
+		return source{}, err
+	}
+	return source{file: f.values(), resource: f.Resource.Attributes}, nil
+}
+
+func (s source) get(key string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return s.file[key]
+}
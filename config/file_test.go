@@ -0,0 +1,187 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfigFile writes content to a temp file and points OTEL_CONFIG_FILE at it, after
+// clearing every other variable Load reads.
+func writeConfigFile(t *testing.T, name, content string, env map[string]string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	setEnv(t, env)
+	t.Setenv("OTEL_CONFIG_FILE", path)
+}
+
+const precedenceFile = `
+service:
+  name: file-service
+  environment: staging
+traces:
+  sampler: traceidratio
+  sampler_arg: 0.25
+logs:
+  level: debug
+propagators: [b3, jaeger]
+`
+
+func TestLoadFilePrecedence(t *testing.T) {
+	type settings struct{ service, environment, sampler, samplerArg, logLevel, propagators string }
+	tests := []struct {
+		name string
+		file string // "" means no OTEL_CONFIG_FILE
+		env  map[string]string
+		want settings
+	}{
+		{
+			name: "defaults without a file",
+			want: settings{DefaultServiceName, EnvironmentDevelopment, SamplerParentBasedAlwaysOn, "", "info", "tracecontext,baggage"},
+		},
+		{
+			name: "file over defaults",
+			file: precedenceFile,
+			want: settings{"file-service", EnvironmentStaging, SamplerTraceIDRatio, "0.25", "debug", "b3,jaeger"},
+		},
+		{
+			name: "env over file",
+			file: precedenceFile,
+			env: map[string]string{
+				"OTEL_SERVICE_NAME":       "env-service",
+				"OTEL_TRACES_SAMPLER_ARG": "0.5",
+				"LOG_LEVEL":               "error",
+				"OTEL_PROPAGATORS":        "tracecontext",
+			},
+			want: settings{"env-service", EnvironmentStaging, SamplerTraceIDRatio, "0.5", "error", "tracecontext"},
+		},
+		{
+			name: "empty env value falls back to the file",
+			file: precedenceFile,
+			env:  map[string]string{"OTEL_SERVICE_NAME": ""},
+			want: settings{"file-service", EnvironmentStaging, SamplerTraceIDRatio, "0.25", "debug", "b3,jaeger"},
+		},
+		{
+			name: "JSON file",
+			file: `{"service": {"name": "json-service"}, "propagators": ["xray"]}`,
+			want: settings{"json-service", EnvironmentDevelopment, SamplerParentBasedAlwaysOn, "", "info", "xray"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.file == "" {
+				setEnv(t, tt.env)
+			} else {
+				writeConfigFile(t, "otel.yaml", tt.file, tt.env)
+			}
+			cfg, err := LoadStrict()
+			if err != nil {
+				t.Fatalf("LoadStrict: %v", err)
+			}
+			got := settings{cfg.ServiceName, cfg.Environment, cfg.Sampler, cfg.SamplerArg, cfg.LogLevel, strings.Join(cfg.Propagators, ",")}
+			if got != tt.want {
+				t.Errorf("settings = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string // substring of the LoadStrict error; "" means no error
+	}{
+		{name: "empty file", file: ""},
+		{name: "comments only", file: "# nothing configured yet\n"},
+		{name: "unknown top-level key", file: "servce:\n  name: orders\n", wantErr: "field servce not found"},
+		{name: "unknown nested key", file: "traces:\n  exporterr: otlp\n", wantErr: "field exporterr not found"},
+		{name: "wrong type", file: "propagators: tracecontext\n", wantErr: "OTEL_CONFIG_FILE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfigFile(t, "otel.yaml", tt.file, nil)
+			_, err := LoadStrict()
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("LoadStrict: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("LoadStrict error = %v, want it to contain %q", err, tt.wantErr)
+			}
+
+			// Load ignores a rejected file entirely and falls back to the defaults.
+			if cfg := Load(); cfg.ServiceName != DefaultServiceName {
+				t.Errorf("Load ServiceName = %q, want %q", cfg.ServiceName, DefaultServiceName)
+			}
+		})
+	}
+}
+
+func TestLoadFileHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		traces  map[string]string
+		metrics map[string]string
+	}{
+		{
+			name: "values needing escapes round-trip",
+			file: `
+exporter:
+  headers:
+    x-tenant: "lms team"
+    authorization: "Bearer a,b=c%d"
+`,
+			traces:  map[string]string{"x-tenant": "lms team", "authorization": "Bearer a,b=c%d"},
+			metrics: map[string]string{"x-tenant": "lms team", "authorization": "Bearer a,b=c%d"},
+		},
+		{
+			name: "per-signal headers merged over shared ones",
+			file: `
+exporter:
+  headers: {x-tenant: lms, x-team: core}
+traces:
+  headers: {x-tenant: "traces only"}
+`,
+			traces:  map[string]string{"x-tenant": "traces only", "x-team": "core"},
+			metrics: map[string]string{"x-tenant": "lms", "x-team": "core"},
+		},
+		{
+			name:    "env replaces the file's headers",
+			file:    "exporter:\n  headers: {x-tenant: lms, x-team: core}\n",
+			env:     map[string]string{"OTEL_EXPORTER_OTLP_HEADERS": "x-tenant=env%20tenant"},
+			traces:  map[string]string{"x-tenant": "env tenant"},
+			metrics: map[string]string{"x-tenant": "env tenant"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfigFile(t, "otel.yaml", tt.file, tt.env)
+			cfg, err := LoadStrict()
+			if err != nil {
+				t.Fatalf("LoadStrict: %v", err)
+			}
+			if !maps.Equal(cfg.Traces.Headers, tt.traces) {
+				t.Errorf("Traces.Headers = %v, want %v", cfg.Traces.Headers, tt.traces)
+			}
+			if !maps.Equal(cfg.Metrics.Headers, tt.metrics) {
+				t.Errorf("Metrics.Headers = %v, want %v", cfg.Metrics.Headers, tt.metrics)
+			}
+		})
+	}
+}
+
+func TestLoadFileResourceAttributes(t *testing.T) {
+	writeConfigFile(t, "otel.yaml", "resource:\n  attributes: {team: lms, tier: gold}\n",
+		map[string]string{"OTEL_RESOURCE_ATTRIBUTES": "tier=platinum,region=eu"})
+	want := map[string]string{"team": "lms", "tier": "platinum", "region": "eu"}
+	if got := Load().ResourceAttributes; !maps.Equal(got, want) {
+		t.Errorf("ResourceAttributes = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// OTLP transport protocols. Env: OTEL_EXPORTER_OTLP_PROTOCOL / OTEL_EXPORTER_OTLP_<SIGNAL>_PROTOCOL
+const (
+	ProtocolGRPC         = "grpc"
+	ProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// Compression values. Env: OTEL_EXPORTER_OTLP_COMPRESSION / OTEL_EXPORTER_OTLP_<SIGNAL>_COMPRESSION
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
+// Signal names used in per-signal env vars (OTEL_EXPORTER_OTLP_<SIGNAL>_*) and OTLP/HTTP paths.
+const (
+	SignalTraces  = "traces"
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+)
+
+// OTLP holds the exporter settings for one signal. Values come from the per-signal
+// OTEL_EXPORTER_OTLP_<SIGNAL>_* env vars, falling back to the generic OTEL_EXPORTER_OTLP_* ones.
+type OTLP struct {
+	// Endpoint is where the exporter sends data. For gRPC it is "host:port" or a URL whose scheme
+	// selects plaintext (http) or TLS (https). For http/protobuf it is always a full URL including
+	// the signal path (e.g., "https://otel.example.com/v1/traces").
+	Endpoint string
+
+	// Protocol is ProtocolGRPC or ProtocolHTTPProtobuf.
+	Protocol string
+
+	// Insecure disables TLS for gRPC endpoints without a scheme. Defaults to true unless a
+	// certificate is configured, so plain "host:4317" endpoints keep working. Env: ..._INSECURE
+	Insecure bool
+
+	// Certificate is the path to a PEM CA bundle used to verify the collector. Empty means
+	// the system roots. Env: ..._CERTIFICATE
+	Certificate string
+
+	// ClientCertificate and ClientKey are PEM file paths for mTLS; both or neither must be set.
+	// Env: ..._CLIENT_CERTIFICATE, ..._CLIENT_KEY
+	ClientCertificate string
+	ClientKey         string
+
+	// Headers are sent with every export request (e.g., API key or tenant header for a gateway).
+	// Per-signal headers are merged over the generic ones, key by key.
+	// Env: ..._HEADERS as "key1=value1,key2=value2" with URL-encoded values
+	Headers map[string]string
+
+	// Compression is CompressionGzip or CompressionNone. Env: ..._COMPRESSION (default "none")
+	Compression string
+}
+
+// HasScheme reports whether Endpoint is a URL (has an http:// or https:// scheme).
+func (o OTLP) HasScheme() bool {
+	return strings.Contains(o.Endpoint, "://")
+}
+
+// Secure reports whether the exporter connection uses TLS: the URL scheme decides when present,
+// otherwise Insecure does.
+func (o OTLP) Secure() bool {
+	if o.HasScheme() {
+		return strings.HasPrefix(strings.ToLower(o.Endpoint), "https://")
+	}
+	return !o.Insecure
+}
+
+// TLSConfig builds the client TLS configuration from Certificate, ClientCertificate and ClientKey.
+// Only meaningful when Secure reports true.
+func (o OTLP) TLSConfig() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if o.Certificate != "" {
+		caPEM, err := os.ReadFile(o.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("read OTLP CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", o.Certificate)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.ClientCertificate != "" || o.ClientKey != "" {
+		if o.ClientCertificate == "" || o.ClientKey == "" {
+			return nil, errors.New("OTLP client certificate and client key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("load OTLP client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// defaultEndpoint returns the local collector endpoint for protocol (4317 for gRPC, 4318 for HTTP).
+func defaultEndpoint(protocol string) string {
+	if protocol == ProtocolHTTPProtobuf {
+		return "http://localhost:4318"
+	}
+	return "localhost:4317"
+}
+
+// loadOTLP resolves the exporter settings for signal. endpoint and protocol are the generic
+// values (endpoint empty when unset); per-signal settings take precedence.
+func loadOTLP(src source, signal, endpoint, protocol string) OTLP {
+	prefix := "OTEL_EXPORTER_OTLP_" + strings.ToUpper(signal) + "_"
+	lookup := func(name string) string {
+		if v := src.get(prefix + name); v != "" {
+			return v
+		}
+		return src.get("OTEL_EXPORTER_OTLP_" + name)
+	}
+
+	if p := strings.ToLower(strings.TrimSpace(src.get(prefix + "PROTOCOL"))); p != "" {
+		protocol = p
+	}
+
+	o := OTLP{
+		Endpoint:          resolveEndpoint(signal, src.get(prefix+"ENDPOINT"), endpoint, protocol),
+		Protocol:          protocol,
+		Certificate:       lookup("CERTIFICATE"),
+		ClientCertificate: lookup("CLIENT_CERTIFICATE"),
+		ClientKey:         lookup("CLIENT_KEY"),
+	}
+
+	o.Headers = parseHeaders(src.get("OTEL_EXPORTER_OTLP_HEADERS"))
+	for k, v := range parseHeaders(src.get(prefix + "HEADERS")) {
+		o.Headers[k] = v
+	}
+
+	o.Compression = strings.ToLower(strings.TrimSpace(lookup("COMPRESSION")))
+	if o.Compression == "" {
+		o.Compression = CompressionNone
+	}
+
+	o.Insecure = o.Certificate == "" && o.ClientCertificate == ""
+	if v, err := strconv.ParseBool(strings.TrimSpace(lookup("INSECURE"))); err == nil {
+		o.Insecure = v
+	}
+	return o
+}
+
+// resolveEndpoint follows the OTLP exporter spec: a per-signal endpoint is used as-is, while the
+// generic endpoint is a base URL to which http/protobuf appends "/v1/<signal>".
+func resolveEndpoint(signal, signalEndpoint, endpoint, protocol string) string {
+	if signalEndpoint != "" {
+		if protocol == ProtocolHTTPProtobuf && !strings.Contains(signalEndpoint, "://") {
+			signalEndpoint = "http://" + signalEndpoint
+		}
+		return signalEndpoint
+	}
+
+	if endpoint == "" {
+		endpoint = defaultEndpoint(protocol)
+	}
+	if protocol != ProtocolHTTPProtobuf {
+		return endpoint
+	}
+
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/v1/" + signal
+}
+
+// parseHeaders parses the OTLP headers format "key1=value1,key2=value2" (W3C Baggage-like,
+// values URL-encoded). Malformed entries are skipped. Always returns a non-nil map.
+func parseHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		if decoded, err := url.PathUnescape(v); err == nil {
+			v = decoded
+		}
+		headers[k] = v
+	}
+	return headers
+}
@@ -0,0 +1,10 @@
+package config
+
+// Resource detectors accepted in OTEL_RESOURCE_DETECTORS.
+const (
+	DetectorHost      = "host"      // host.name, host.id
+	DetectorProcess   = "process"   // process.pid, process.executable.*, process.runtime.*, ...
+	DetectorOS        = "os"        // os.type, os.description
+	DetectorContainer = "container" // container.id from /proc/self/cgroup
+	DetectorK8s       = "k8s"       // k8s.* from downward-API env vars
+)
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Sampler names accepted in OTEL_TRACES_SAMPLER.
+const (
+	SamplerAlwaysOn                = "always_on"
+	SamplerAlwaysOff               = "always_off"
+	SamplerTraceIDRatio            = "traceidratio"
+	SamplerParentBasedAlwaysOn     = "parentbased_always_on"
+	SamplerParentBasedAlwaysOff    = "parentbased_always_off"
+	SamplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+)
+
+// Sampling rule kinds: which span a rule's Pattern is matched against.
+const (
+	RuleHTTPRoute  = "http"  // raw HTTP request path (not the route template), e.g. "/health" or "/orders/*"
+	RuleGRPCMethod = "grpc"  // gRPC full method, e.g. "/grpc.health.v1.Health/*"
+	RuleKafkaTopic = "kafka" // Kafka topic, e.g. "audit-events"
+)
+
+// SamplingRule overrides the sampling ratio for root spans of one HTTP route, gRPC method or
+// Kafka topic. The first matching rule wins; spans matching no rule use the configured sampler.
+type SamplingRule struct {
+	// Kind is RuleHTTPRoute, RuleGRPCMethod or RuleKafkaTopic.
+	Kind string
+
+	// Pattern is matched exactly, or as a prefix when it ends with "*". HTTP patterns match the
+	// raw request path: the route template is only resolved after the handler returns, too late
+	// for the sampling decision, so "/orders/{id}" never matches and "/orders/*" must be used.
+	Pattern string
+
+	// Ratio is the fraction of matching traces to sample, from 0 (drop all) to 1 (keep all).
+	Ratio float64
+}
+
+// parseSamplingRules parses OTEL_TRACES_SAMPLER_RULES: comma-separated "kind:pattern=ratio"
+// entries, e.g. "http:/health*=0,grpc:/orders.v1.Orders/*=0.5,kafka:audit-events=0.01".
+// Malformed entries are skipped.
+func parseSamplingRules(raw string) []SamplingRule {
+	var rules []SamplingRule
+	for _, entry := range strings.Split(raw, ",") {
+		if rule, ok := parseSamplingRule(entry); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseSamplingRule parses one "kind:pattern=ratio" entry.
+func parseSamplingRule(entry string) (SamplingRule, bool) {
+	entry = strings.TrimSpace(entry)
+	kind, rest, ok := strings.Cut(entry, ":")
+	if !ok {
+		return SamplingRule{}, false
+	}
+	i := strings.LastIndex(rest, "=")
+	if i <= 0 {
+		return SamplingRule{}, false
+	}
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(rest[i+1:]), 64)
+	if err != nil {
+		return SamplingRule{}, false
+	}
+	return SamplingRule{
+		Kind:    strings.ToLower(strings.TrimSpace(kind)),
+		Pattern: strings.TrimSpace(rest[:i]),
+		Ratio:   ratio,
+	}, true
+}
+
+// loadSampler reads OTEL_TRACES_SAMPLER, OTEL_TRACES_SAMPLER_ARG and OTEL_TRACES_SAMPLER_RULES.
+func loadSampler(src source) (sampler, arg string, rules []SamplingRule) {
+	sampler = strings.ToLower(strings.TrimSpace(src.get("OTEL_TRACES_SAMPLER")))
+	if sampler == "" {
+		sampler = SamplerParentBasedAlwaysOn
+	}
+	arg = strings.TrimSpace(src.get("OTEL_TRACES_SAMPLER_ARG"))
+	rules = parseSamplingRules(src.get("OTEL_TRACES_SAMPLER_RULES"))
+	return sampler, arg, rules
+}
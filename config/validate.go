@@ -0,0 +1,216 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultServiceName is used by Load when neither OTEL_SERVICE_NAME nor a service.name resource
+// attribute is set.
+const DefaultServiceName = "unknown-service"
+
+// Deployment environments accepted by Validate in OTEL_ENVIRONMENT.
+const (
+	EnvironmentLocal       = "local"
+	EnvironmentDevelopment = "development"
+	EnvironmentTest        = "test"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "production"
+)
+
+// environments maps accepted OTEL_ENVIRONMENT values (including common short forms) to
+// whether they are production.
+var environments = map[string]bool{
+	EnvironmentLocal:       false,
+	EnvironmentDevelopment: false,
+	"dev":                  false,
+	EnvironmentTest:        false,
+	"qa":                   false,
+	EnvironmentStaging:     false,
+	"stage":                false,
+	EnvironmentProduction:  true,
+	"prod":                 true,
+}
+
+// IsProduction reports whether Environment names a production deployment ("production" or "prod").
+func (c *Config) IsProduction() bool {
+	return environments[strings.ToLower(c.Environment)]
+}
+
+// LoadStrict reads configuration like Load and validates it, also rejecting values Load would
+// silently skip (an unreadable OTEL_CONFIG_FILE, unknown file keys, malformed
+// OTEL_TRACES_SAMPLER_RULES entries). The returned error joins every problem found, so a
+// misconfigured deploy fails once with the full list; the Config is returned either way.
+func LoadStrict() (*Config, error) {
+	var errs []error
+	src, err := newSource()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	cfg := load(src)
+	for _, entry := range strings.Split(src.get("OTEL_TRACES_SAMPLER_RULES"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		if _, ok := parseSamplingRule(entry); !ok {
+			errs = append(errs, fmt.Errorf("OTEL_TRACES_SAMPLER_RULES: malformed rule %q, want kind:pattern=ratio", entry))
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return cfg, errors.Join(errs...)
+}
+
+// Validate checks the configuration and returns all problems joined with errors.Join, or nil:
+// service name and environment (in production also a real service name and a version),
+// known protocol, compression, sampler, exporter and propagator names, sampler ratios, and,
+// for signals exported over OTLP, endpoint syntax and TLS files.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if strings.TrimSpace(c.ServiceName) == "" {
+		add("OTEL_SERVICE_NAME: required")
+	}
+	if _, ok := environments[strings.ToLower(c.Environment)]; !ok {
+		add("OTEL_ENVIRONMENT: unknown environment %q, want one of local, development, test, staging, production", c.Environment)
+	}
+	if c.IsProduction() {
+		if c.ServiceName == DefaultServiceName {
+			add("OTEL_SERVICE_NAME: required in production")
+		}
+		if c.ServiceVersion == "" {
+			add("OTEL_SERVICE_VERSION: required in production")
+		}
+	}
+
+	if c.Protocol != ProtocolGRPC && c.Protocol != ProtocolHTTPProtobuf {
+		add("OTEL_EXPORTER_OTLP_PROTOCOL: unknown protocol %q, want grpc or http/protobuf", c.Protocol)
+	}
+
+	switch c.Sampler {
+	case SamplerAlwaysOn, SamplerAlwaysOff, SamplerParentBasedAlwaysOn, SamplerParentBasedAlwaysOff:
+	case SamplerTraceIDRatio, SamplerParentBasedTraceIDRatio:
+		if c.SamplerArg != "" {
+			if r, err := strconv.ParseFloat(c.SamplerArg, 64); err != nil || r < 0 || r > 1 {
+				add("OTEL_TRACES_SAMPLER_ARG: %q is not a ratio between 0 and 1", c.SamplerArg)
+			}
+		}
+	default:
+		add("OTEL_TRACES_SAMPLER: unknown sampler %q", c.Sampler)
+	}
+	for _, r := range c.SamplingRules {
+		if r.Kind != RuleHTTPRoute && r.Kind != RuleGRPCMethod && r.Kind != RuleKafkaTopic {
+			add("OTEL_TRACES_SAMPLER_RULES: unknown rule kind %q, want http, grpc or kafka", r.Kind)
+		}
+		if r.Ratio < 0 || r.Ratio > 1 {
+			add("OTEL_TRACES_SAMPLER_RULES: ratio %g for %q is not between 0 and 1", r.Ratio, r.Pattern)
+		}
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "error", "":
+	default:
+		add("LOG_LEVEL: unknown level %q, want debug, info or error", c.LogLevel)
+	}
+
+	for _, p := range c.Propagators {
+		switch p {
+		case "tracecontext", "baggage", "b3", "b3multi", "jaeger", "xray", "none":
+		default:
+			add("OTEL_PROPAGATORS: unknown propagator %q", p)
+		}
+	}
+
+	for _, d := range c.ResourceDetectors {
+		switch d {
+		case DetectorHost, DetectorProcess, DetectorOS, DetectorContainer, DetectorK8s:
+		default:
+			add("OTEL_RESOURCE_DETECTORS: unknown detector %q, want host, process, os, container or k8s", d)
+		}
+	}
+
+	signals := []struct {
+		signal, exporter string
+		otlp             OTLP
+	}{
+		{SignalTraces, c.TracesExporter, c.Traces},
+		{SignalMetrics, c.MetricsExporter, c.Metrics},
+		{SignalLogs, c.LogsExporter, c.Logs},
+	}
+	for _, s := range signals {
+		key := "OTEL_" + strings.ToUpper(s.signal) + "_EXPORTER"
+		switch s.exporter {
+		case ExporterOTLP:
+			errs = append(errs, s.otlp.validate(s.signal)...)
+		case ExporterConsole, ExporterFile, ExporterNone, "":
+		default:
+			add("%s: unknown exporter %q, want otlp, console, file or none", key, s.exporter)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks one signal's OTLP settings. Errors name the signal rather than an env var,
+// since the value may come from the generic or the per-signal variable.
+func (o OTLP) validate(signal string) []error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s OTLP %s", signal, fmt.Sprintf(format, args...)))
+	}
+
+	switch o.Protocol {
+	case ProtocolGRPC:
+		if o.HasScheme() {
+			if err := validateURL(o.Endpoint); err != nil {
+				add("endpoint: %v", err)
+			}
+		} else if _, port, err := net.SplitHostPort(o.Endpoint); err != nil || !validPort(port) {
+			add("endpoint: %q is neither host:port nor an http(s) URL", o.Endpoint)
+		}
+	case ProtocolHTTPProtobuf:
+		if err := validateURL(o.Endpoint); err != nil {
+			add("endpoint: %v", err)
+		}
+	default:
+		add("protocol: unknown protocol %q, want grpc or http/protobuf", o.Protocol)
+	}
+
+	if o.Compression != CompressionGzip && o.Compression != CompressionNone {
+		add("compression: unknown compression %q, want gzip or none", o.Compression)
+	}
+	if o.Secure() && (o.Certificate != "" || o.ClientCertificate != "" || o.ClientKey != "") {
+		if _, err := o.TLSConfig(); err != nil {
+			add("TLS: %v", err)
+		}
+	}
+	return errs
+}
+
+func validPort(port string) bool {
+	_, err := strconv.ParseUint(port, 10, 16)
+	return err == nil
+}
+
+// validateURL checks that endpoint is an absolute http(s) URL with a host.
+func validateURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q: scheme must be http or https", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q: missing host", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+// errorMessages flattens errors.Join trees into their leaf messages, in order.
+func errorMessages(err error) []string {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var msgs []string
+		for _, e := range joined.Unwrap() {
+			msgs = append(msgs, errorMessages(e)...)
+		}
+		return msgs
+	}
+	return []string{err.Error()}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		mutate func(*Config) // applied after Load, for values Load never produces
+		want   []string
+	}{
+		{name: "defaults are valid"},
+		{
+			name: "valid production",
+			env:  map[string]string{"OTEL_ENVIRONMENT": "prod", "OTEL_SERVICE_NAME": "orders", "OTEL_SERVICE_VERSION": "1.4.2"},
+		},
+		{
+			name:   "service name required",
+			mutate: func(c *Config) { c.ServiceName = " " },
+			want:   []string{"OTEL_SERVICE_NAME: required"},
+		},
+		{
+			name: "production requires a real service name and a version",
+			env:  map[string]string{"OTEL_ENVIRONMENT": "production"},
+			want: []string{
+				"OTEL_SERVICE_NAME: required in production",
+				"OTEL_SERVICE_VERSION: required in production",
+			},
+		},
+		{
+			name: "unknown environment",
+			env:  map[string]string{"OTEL_ENVIRONMENT": "sandbox"},
+			want: []string{`OTEL_ENVIRONMENT: unknown environment "sandbox", want one of local, development, test, staging, production`},
+		},
+		{
+			name: "unknown protocol",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json"},
+			want: []string{
+				`OTEL_EXPORTER_OTLP_PROTOCOL: unknown protocol "http/json", want grpc or http/protobuf`,
+				`traces OTLP protocol: unknown protocol "http/json", want grpc or http/protobuf`,
+				`metrics OTLP protocol: unknown protocol "http/json", want grpc or http/protobuf`,
+			},
+		},
+		{
+			name: "unknown sampler",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER": "ratio"},
+			want: []string{`OTEL_TRACES_SAMPLER: unknown sampler "ratio"`},
+		},
+		{
+			name: "sampler ratio lower bound",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER": "traceidratio", "OTEL_TRACES_SAMPLER_ARG": "0"},
+		},
+		{
+			name: "sampler ratio upper bound",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER": "parentbased_traceidratio", "OTEL_TRACES_SAMPLER_ARG": "1"},
+		},
+		{
+			name: "sampler ratio above 1",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER": "traceidratio", "OTEL_TRACES_SAMPLER_ARG": "1.5"},
+			want: []string{`OTEL_TRACES_SAMPLER_ARG: "1.5" is not a ratio between 0 and 1`},
+		},
+		{
+			name: "sampler ratio below 0",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER": "traceidratio", "OTEL_TRACES_SAMPLER_ARG": "-0.1"},
+			want: []string{`OTEL_TRACES_SAMPLER_ARG: "-0.1" is not a ratio between 0 and 1`},
+		},
+		{
+			name: "sampler ratio not a number",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER": "parentbased_traceidratio", "OTEL_TRACES_SAMPLER_ARG": "half"},
+			want: []string{`OTEL_TRACES_SAMPLER_ARG: "half" is not a ratio between 0 and 1`},
+		},
+		{
+			name: "sampling rules",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER_RULES": "http:/health=0,http:/x=2,ftp:/y=0.5"},
+			want: []string{
+				`OTEL_TRACES_SAMPLER_RULES: ratio 2 for "/x" is not between 0 and 1`,
+				`OTEL_TRACES_SAMPLER_RULES: unknown rule kind "ftp", want http, grpc or kafka`,
+			},
+		},
+		{
+			name: "unknown log level",
+			env:  map[string]string{"LOG_LEVEL": "trace"},
+			want: []string{`LOG_LEVEL: unknown level "trace", want debug, info or error`},
+		},
+		{
+			name: "unknown propagator",
+			env:  map[string]string{"OTEL_PROPAGATORS": "tracecontext,w3c"},
+			want: []string{`OTEL_PROPAGATORS: unknown propagator "w3c"`},
+		},
+		{
+			name: "unknown detector",
+			env:  map[string]string{"OTEL_RESOURCE_DETECTORS": "host,gcp"},
+			want: []string{`OTEL_RESOURCE_DETECTORS: unknown detector "gcp", want host, process, os, container or k8s`},
+		},
+		{
+			name: "unknown exporter",
+			env:  map[string]string{"OTEL_METRICS_EXPORTER": "prometheus"},
+			want: []string{`OTEL_METRICS_EXPORTER: unknown exporter "prometheus", want otlp, console, file or none`},
+		},
+		{
+			name: "OTLP endpoint checked for every OTLP signal",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "localhost", "OTEL_LOGS_EXPORTER": "otlp"},
+			want: []string{
+				`traces OTLP endpoint: "localhost" is neither host:port nor an http(s) URL`,
+				`metrics OTLP endpoint: "localhost" is neither host:port nor an http(s) URL`,
+				`logs OTLP endpoint: "localhost" is neither host:port nor an http(s) URL`,
+			},
+		},
+		{
+			name: "OTLP settings ignored for other exporters",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "localhost", "OTEL_TRACES_EXPORTER": "console", "OTEL_METRICS_EXPORTER": "none"},
+		},
+		{
+			name: "http/protobuf endpoint must be a URL",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "ftp://collector/v1/traces"},
+			want: []string{`traces OTLP endpoint: "ftp://collector/v1/traces": scheme must be http or https`},
+		},
+		{
+			name: "unknown compression",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_COMPRESSION": "zstd"},
+			want: []string{
+				`traces OTLP compression: unknown compression "zstd", want gzip or none`,
+				`metrics OTLP compression: unknown compression "zstd", want gzip or none`,
+			},
+		},
+		{
+			name: "several problems reported together",
+			env: map[string]string{
+				"OTEL_ENVIRONMENT":        "prod",
+				"OTEL_TRACES_SAMPLER":     "sometimes",
+				"LOG_LEVEL":               "verbose",
+				"OTEL_PROPAGATORS":        "w3c",
+				"OTEL_RESOURCE_DETECTORS": "gcp",
+			},
+			want: []string{
+				"OTEL_SERVICE_NAME: required in production",
+				"OTEL_SERVICE_VERSION: required in production",
+				`OTEL_TRACES_SAMPLER: unknown sampler "sometimes"`,
+				`LOG_LEVEL: unknown level "verbose", want debug, info or error`,
+				`OTEL_PROPAGATORS: unknown propagator "w3c"`,
+				`OTEL_RESOURCE_DETECTORS: unknown detector "gcp", want host, process, os, container or k8s`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			cfg := Load()
+			if tt.mutate != nil {
+				tt.mutate(cfg)
+			}
+			if got := errorMessages(cfg.Validate()); !slices.Equal(got, tt.want) {
+				t.Errorf("Validate() =\n  %q\nwant\n  %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want []string
+	}{
+		{name: "valid"},
+		{
+			name: "malformed sampling rules",
+			env:  map[string]string{"OTEL_TRACES_SAMPLER_RULES": "http:/health=0, bad ,kafka:audit"},
+			want: []string{
+				`OTEL_TRACES_SAMPLER_RULES: malformed rule " bad ", want kind:pattern=ratio`,
+				`OTEL_TRACES_SAMPLER_RULES: malformed rule "kafka:audit", want kind:pattern=ratio`,
+			},
+		},
+		{
+			name: "file, rule and validation errors together",
+			env: map[string]string{
+				"OTEL_CONFIG_FILE":          "/nonexistent/otel.yaml",
+				"OTEL_TRACES_SAMPLER_RULES": "nope",
+				"LOG_LEVEL":                 "trace",
+			},
+			want: []string{
+				"OTEL_CONFIG_FILE: open /nonexistent/otel.yaml: no such file or directory",
+				`OTEL_TRACES_SAMPLER_RULES: malformed rule "nope", want kind:pattern=ratio`,
+				`LOG_LEVEL: unknown level "trace", want debug, info or error`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			cfg, err := LoadStrict()
+			if cfg == nil {
+				t.Fatal("LoadStrict returned a nil Config")
+			}
+			if got := errorMessages(err); !slices.Equal(got, tt.want) {
+				t.Errorf("LoadStrict() =\n  %q\nwant\n  %q", got, tt.want)
+			}
+		})
+	}
+}
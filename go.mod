@@ -3,18 +3,42 @@ module github.com/MH-Cognition/mhc-infra-observability
 go 1.24.0
 
 require (
-	// OpenTelemetry (ALL SAME VERSION — VERY IMPORTANT)
+	// Propagators for non-W3C upstreams (B3, Jaeger, AWS X-Ray)
+	go.opentelemetry.io/contrib/propagators/aws v1.37.0
+	go.opentelemetry.io/contrib/propagators/b3 v1.39.0
+	go.opentelemetry.io/contrib/propagators/jaeger v1.37.0
+
+	// OpenTelemetry (ALL SAME VERSION — VERY IMPORTANT; log modules use the matching v0.x line)
 	go.opentelemetry.io/otel v1.39.0
 
-	// OTLP exporter
+	// OTLP exporters
+	go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc v0.15.0
+	go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp v0.14.0
+	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc v1.39.0
+	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp v1.39.0
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.39.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.39.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.39.0
+
+	// Console / file exporters for local development
+	go.opentelemetry.io/otel/exporters/stdout/stdoutlog v0.14.0
+	go.opentelemetry.io/otel/exporters/stdout/stdoutmetric v1.39.0
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.39.0
+
+	// OpenTelemetry API and SDK
+	go.opentelemetry.io/otel/log v0.15.0
 	go.opentelemetry.io/otel/metric v1.39.0
 	go.opentelemetry.io/otel/sdk v1.39.0
+	go.opentelemetry.io/otel/sdk/log v0.15.0
+	go.opentelemetry.io/otel/sdk/metric v1.39.0
 	go.opentelemetry.io/otel/trace v1.39.0
 
 	// gRPC
 	google.golang.org/grpc v1.77.0
+	google.golang.org/protobuf v1.36.10
+
+	// OTEL_CONFIG_FILE parsing (YAML and JSON)
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -37,5 +61,4 @@ require (
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20251202230838-ff82c1b0f217 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
-	google.golang.org/protobuf v1.36.10 // indirect
 )
@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// newExporter creates the OTLP log exporter for the configured protocol, transport security,
+// headers and compression.
+func newExporter(ctx context.Context, o config.OTLP) (sdklog.Exporter, error) {
+	switch o.Protocol {
+	case config.ProtocolGRPC:
+		var opts []otlploggrpc.Option
+		if o.HasScheme() {
+			opts = append(opts, otlploggrpc.WithEndpointURL(o.Endpoint))
+		} else {
+			opts = append(opts, otlploggrpc.WithEndpoint(o.Endpoint))
+		}
+		if len(o.Headers) > 0 {
+			opts = append(opts, otlploggrpc.WithHeaders(o.Headers))
+		}
+		if o.Compression == config.CompressionGzip {
+			opts = append(opts, otlploggrpc.WithCompressor(config.CompressionGzip))
+		}
+		if o.Secure() {
+			tlsCfg, err := o.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		} else {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		}
+		return otlploggrpc.New(ctx, opts...)
+	case config.ProtocolHTTPProtobuf:
+		opts := []otlploghttp.Option{otlploghttp.WithEndpointURL(o.Endpoint)}
+		if len(o.Headers) > 0 {
+			opts = append(opts, otlploghttp.WithHeaders(o.Headers))
+		}
+		if o.Compression == config.CompressionGzip {
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		}
+		if o.Secure() {
+			tlsCfg, err := o.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlploghttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlploghttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", o.Protocol)
+	}
+}
@@ -0,0 +1,231 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// traceHandler adds trace_id and span_id from the record's context to every stdout record.
+type traceHandler struct {
+	next slog.Handler
+}
+
+func (h traceHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	tc := FromContext(ctx)
+	if tc.TraceID == "" && tc.SpanID == "" {
+		return h.next.Handle(ctx, r)
+	}
+	// Rebuild the record so trace fields come before the call-site attributes.
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	nr.AddAttrs(slog.String("trace_id", tc.TraceID), slog.String("span_id", tc.SpanID))
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(a)
+		return true
+	})
+	return h.next.Handle(ctx, nr)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{next: h.next.WithGroup(name)}
+}
+
+// fanoutHandler dispatches each record to every handler that has the level enabled.
+type fanoutHandler []slog.Handler
+
+func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, hh := range h {
+		if hh.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, hh := range h {
+		if !hh.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := hh.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanoutHandler, len(h))
+	for i, hh := range h {
+		out[i] = hh.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	out := make(fanoutHandler, len(h))
+	for i, hh := range h {
+		out[i] = hh.WithGroup(name)
+	}
+	return out
+}
+
+// otelHandler converts slog records into OpenTelemetry log records.
+// Trace and span IDs are taken from ctx by the SDK, so they are first-class record fields
+// rather than string attributes.
+type otelHandler struct {
+	logger otellog.Logger
+	level  slog.Leveler
+	attrs  []otellog.KeyValue // attributes added outside any group
+	groups []otelGroup        // open groups, outermost first
+}
+
+// otelGroup is a group opened via WithGroup together with the attributes added inside it.
+type otelGroup struct {
+	name  string
+	attrs []otellog.KeyValue
+}
+
+func (h *otelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if level < h.level.Level() {
+		return false
+	}
+	return h.logger.Enabled(ctx, otellog.EnabledParameters{Severity: severity(level)})
+}
+
+func (h *otelHandler) Handle(ctx context.Context, r slog.Record) error {
+	var rec otellog.Record
+	rec.SetTimestamp(r.Time)
+	rec.SetBody(otellog.StringValue(r.Message))
+	rec.SetSeverity(severity(r.Level))
+	rec.SetSeverityText(r.Level.String())
+
+	attrs := make([]otellog.KeyValue, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendAttr(attrs, a)
+		return true
+	})
+
+	// Nest record attributes into open groups, innermost first.
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		g := h.groups[i]
+		inner := append(append([]otellog.KeyValue{}, g.attrs...), attrs...)
+		if len(inner) == 0 {
+			attrs = nil
+			continue
+		}
+		attrs = []otellog.KeyValue{otellog.Map(g.name, inner...)}
+	}
+	rec.AddAttributes(h.attrs...)
+	rec.AddAttributes(attrs...)
+
+	h.logger.Emit(ctx, rec)
+	return nil
+}
+
+func (h *otelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	kvs := make([]otellog.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = appendAttr(kvs, a)
+	}
+	out := h.clone()
+	if len(out.groups) == 0 {
+		out.attrs = append(out.attrs, kvs...)
+		return out
+	}
+	last := &out.groups[len(out.groups)-1]
+	last.attrs = append(last.attrs, kvs...)
+	return out
+}
+
+func (h *otelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	out := h.clone()
+	out.groups = append(out.groups, otelGroup{name: name})
+	return out
+}
+
+func (h *otelHandler) clone() *otelHandler {
+	out := &otelHandler{
+		logger: h.logger,
+		level:  h.level,
+		attrs:  append([]otellog.KeyValue{}, h.attrs...),
+		groups: make([]otelGroup, len(h.groups)),
+	}
+	for i, g := range h.groups {
+		out.groups[i] = otelGroup{name: g.name, attrs: append([]otellog.KeyValue{}, g.attrs...)}
+	}
+	return out
+}
+
+// severity maps slog levels onto the OpenTelemetry severity range (Debug=5, Info=9, Warn=13, Error=17).
+func severity(level slog.Level) otellog.Severity {
+	return otellog.Severity(level + 9)
+}
+
+// appendAttr converts a slog attribute to OpenTelemetry log attributes and appends them to kvs.
+// Empty attributes are dropped and groups with an empty key are inlined, matching slog handler rules.
+func appendAttr(kvs []otellog.KeyValue, a slog.Attr) []otellog.KeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return kvs
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return append(kvs, otellog.KeyValue{Key: a.Key, Value: convertValue(a.Value)})
+	}
+	var members []otellog.KeyValue
+	for _, ga := range a.Value.Group() {
+		members = appendAttr(members, ga)
+	}
+	if a.Key == "" {
+		return append(kvs, members...)
+	}
+	if len(members) == 0 {
+		return kvs
+	}
+	return append(kvs, otellog.Map(a.Key, members...))
+}
+
+func convertValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.StringValue(v.Duration().String())
+	case slog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	}
+	switch x := v.Any().(type) {
+	case error:
+		return otellog.StringValue(x.Error())
+	case []byte:
+		return otellog.BytesValue(x)
+	case fmt.Stringer:
+		return otellog.StringValue(x.String())
+	default:
+		return otellog.StringValue(fmt.Sprint(x))
+	}
+}
@@ -20,7 +20,9 @@ type Logger struct {
 	inner *slog.Logger
 }
 
-// New creates a Logger with the given level. Uses JSON handler for production.
+// New creates a Logger with the given level. Uses JSON handler on stdout for production (or the
+// handler set via SetHandler) and, when a logs exporter is enabled via Init, also emits every
+// record to the LoggerProvider.
 func New(level Level) *Logger {
 	var slogLevel slog.Level
 	switch level {
@@ -34,12 +36,20 @@ func New(level Level) *Logger {
 		slogLevel = slog.LevelInfo
 	}
 
-	opts := &slog.HandlerOptions{Level: slogLevel}
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	base := getHandler()
+	if base == nil {
+		base = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slogLevel})
+	}
+	var handler slog.Handler = traceHandler{next: base}
+	if ol := getOtelLogger(); ol != nil {
+		handler = fanoutHandler{handler, &otelHandler{logger: ol, level: slogLevel}}
+	}
 	return &Logger{inner: slog.New(handler)}
 }
 
 // WithTrace adds trace_id and span_id to log attributes when present in ctx.
+// Info, Error and Debug already enrich records from ctx; use this only when passing
+// the *slog.Logger to code that does not propagate ctx.
 func (l *Logger) WithTrace(ctx context.Context) *slog.Logger {
 	tc := FromContext(ctx)
 	if tc.TraceID == "" && tc.SpanID == "" {
@@ -57,17 +67,17 @@ func (l *Logger) WithTrace(ctx context.Context) *slog.Logger {
 
 // Info logs at info level with trace context from ctx.
 func (l *Logger) Info(ctx context.Context, msg string, args ...any) {
-	l.WithTrace(ctx).Info(msg, args...)
+	l.inner.InfoContext(ctx, msg, args...)
 }
 
 // Error logs at error level with trace context from ctx.
 func (l *Logger) Error(ctx context.Context, msg string, args ...any) {
-	l.WithTrace(ctx).Error(msg, args...)
+	l.inner.ErrorContext(ctx, msg, args...)
 }
 
 // Debug logs at debug level with trace context from ctx.
 func (l *Logger) Debug(ctx context.Context, msg string, args ...any) {
-	l.WithTrace(ctx).Debug(msg, args...)
+	l.inner.DebugContext(ctx, msg, args...)
 }
 
 // ParseLevel converts string to Level. Defaults to Info for unknown values.
@@ -84,8 +94,12 @@ func ParseLevel(s string) Level {
 	}
 }
 
-// LoggerFromEnv creates a Logger using LOG_LEVEL env var.
+// LoggerFromEnv creates a Logger using LOG_LEVEL env var, falling back to the config.Config.LogLevel
+// passed to Init (which may come from OTEL_CONFIG_FILE).
 func LoggerFromEnv() *Logger {
 	levelStr := os.Getenv("LOG_LEVEL")
+	if levelStr == "" {
+		levelStr = getLevel()
+	}
 	return New(ParseLevel(levelStr))
 }
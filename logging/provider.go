@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const loggerName = "mhc-infra-observability"
+
+var (
+	mu          sync.RWMutex
+	otelLogger  otellog.Logger // set in Init when a logs exporter is enabled
+	baseHandler slog.Handler   // set in Init via WithHandler; nil means JSON to stdout
+	cfgLevel    string         // cfg.LogLevel set in Init; LoggerFromEnv's fallback for LOG_LEVEL
+)
+
+// InitOption customizes Init.
+type InitOption func(*initOptions)
+
+type initOptions struct {
+	handler slog.Handler
+}
+
+// WithHandler replaces the JSON-to-stdout handler used by New. The handler applies its own
+// level filtering; OTLP export (when enabled) still receives every record at the LOG_LEVEL.
+func WithHandler(h slog.Handler) InitOption {
+	return func(o *initOptions) {
+		o.handler = h
+	}
+}
+
+// SetHandler sets the handler New uses instead of JSON to stdout. Called from Init;
+// pass nil to restore the default.
+func SetHandler(h slog.Handler) {
+	mu.Lock()
+	defer mu.Unlock()
+	baseHandler = h
+}
+
+func setLevel(level string) {
+	mu.Lock()
+	defer mu.Unlock()
+	cfgLevel = level
+}
+
+func getLevel() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return cfgLevel
+}
+
+func getHandler() slog.Handler {
+	mu.RLock()
+	defer mu.RUnlock()
+	return baseHandler
+}
+
+// SetLoggerProvider sets the OpenTelemetry LoggerProvider that New fans out to in addition
+// to stdout. Called from Init; pass nil to go back to stdout-only logging.
+func SetLoggerProvider(lp otellog.LoggerProvider) {
+	mu.Lock()
+	defer mu.Unlock()
+	if lp == nil {
+		otelLogger = nil
+		return
+	}
+	otelLogger = lp.Logger(loggerName)
+}
+
+func getOtelLogger() otellog.Logger {
+	mu.RLock()
+	defer mu.RUnlock()
+	return otelLogger
+}
+
+// Init initializes the OpenTelemetry LoggerProvider with the exporter selected by cfg.LogsExporter:
+// "otlp" (per cfg.Logs) and "file" export alongside stdout JSON (or the WithHandler handler),
+// "console" prints human-readable lines instead of stdout JSON, and "none" keeps stdout only.
+// Uses the single Resource created by observability.NewResource (do not create resource here).
+// Returns a shutdown function that flushes pending log records.
+func Init(ctx context.Context, res *resource.Resource, cfg *config.Config, opts ...InitOption) (func(context.Context) error, error) {
+	o := &initOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.handler != nil {
+		SetHandler(o.handler)
+	}
+	setLevel(cfg.LogLevel)
+
+	exporter, err := newLogExporter(ctx, cfg)
+	if err != nil {
+		SetHandler(nil)
+		setLevel("")
+		return nil, fmt.Errorf("create %s log exporter: %w", cfg.LogsExporter, err)
+	}
+	if exporter == nil {
+		return func(context.Context) error {
+			SetHandler(nil)
+			setLevel("")
+			return nil
+		}, nil
+	}
+
+	processor := sdklog.Processor(sdklog.NewBatchProcessor(exporter))
+	if cfg.LogsExporter == config.ExporterConsole {
+		// The console replaces stdout JSON and prints records as they are emitted.
+		processor = sdklog.NewSimpleProcessor(exporter)
+		if o.handler == nil {
+			SetHandler(slog.DiscardHandler)
+		}
+	}
+
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(processor),
+		sdklog.WithResource(res),
+	)
+
+	SetLoggerProvider(lp)
+
+	shutdown := func(ctx context.Context) error {
+		SetLoggerProvider(nil)
+		SetHandler(nil)
+		setLevel("")
+		if err := lp.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutdown logger provider: %w", err)
+		}
+		return nil
+	}
+
+	return shutdown, nil
+}
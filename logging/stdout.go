@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// newLogExporter creates the log exporter selected by cfg.LogsExporter, or nil for "none".
+func newLogExporter(ctx context.Context, cfg *config.Config) (sdklog.Exporter, error) {
+	switch cfg.LogsExporter {
+	case config.ExporterOTLP:
+		return newExporter(ctx, cfg.Logs)
+	case config.ExporterConsole:
+		return &consoleExporter{w: os.Stdout}, nil
+	case config.ExporterFile:
+		f, err := os.OpenFile(cfg.ExporterFilePath(config.SignalLogs), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		exp, err := stdoutlog.New(stdoutlog.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &fileExporter{Exporter: exp, f: f}, nil
+	case config.ExporterNone, "":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported logs exporter %q", cfg.LogsExporter)
+}
+
+// consoleExporter prints each log record as one human-readable line, for local development:
+//
+//	12:04:05.123 INFO  order created order_id=42 trace=4bf9… span=00f0…
+type consoleExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (e *consoleExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s %-5s %s", r.Timestamp().Format("15:04:05.000"), r.SeverityText(), r.Body().String())
+		r.WalkAttributes(func(kv otellog.KeyValue) bool {
+			fmt.Fprintf(&b, " %s=%s", kv.Key, kv.Value.String())
+			return true
+		})
+		if r.TraceID().IsValid() {
+			fmt.Fprintf(&b, " trace=%s span=%s", r.TraceID(), r.SpanID())
+		}
+		b.WriteByte('\n')
+		if _, err := io.WriteString(e.w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *consoleExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *consoleExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// fileExporter closes the JSON-lines file on shutdown.
+type fileExporter struct {
+	sdklog.Exporter
+	f *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if cerr := e.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
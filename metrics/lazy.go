@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Lazy holds a set of instruments created from the current meter. Instrumentation (HTTP
+// middleware, gRPC handlers, ...) is often constructed before Init sets the meter, so instruments
+// are built on first use and rebuilt whenever SetMeter installs a new meter.
+type Lazy[T any] struct {
+	build func(metric.Meter) T
+	cur   atomic.Pointer[lazyValue[T]]
+}
+
+type lazyValue[T any] struct {
+	gen uint64
+	val T
+}
+
+// NewLazy returns a Lazy that creates its instruments with build.
+func NewLazy[T any](build func(metric.Meter) T) *Lazy[T] {
+	return &Lazy[T]{build: build}
+}
+
+// Get returns the instruments for the current meter, building them if the meter changed.
+func (l *Lazy[T]) Get() T {
+	gen := generation.Load()
+	if v := l.cur.Load(); v != nil && v.gen == gen {
+		return v.val
+	}
+	v := &lazyValue[T]{gen: gen, val: l.build(getMeter())}
+	l.cur.Store(v)
+	return v.val
+}
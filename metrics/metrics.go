@@ -1,20 +1,23 @@
 // Package metrics provides minimal OpenTelemetry metrics helpers.
 // Kept minimal per design; services can extend with custom meters.
-// Does not import go.opentelemetry.io/otel so the auto/sdk chain is never pulled in.
+// Does not import go.opentelemetry.io/otel so the auto/sdk chain is never pulled in: only the
+// metric API is used here, and observability.Init builds the MeterProvider and calls SetMeter.
 package metrics
 
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 )
 
 var (
-	mu        sync.RWMutex
-	meter     metric.Meter
-	meterName = "mhc-infra-observability"
+	mu         sync.RWMutex
+	meter      metric.Meter
+	meterName  = "mhc-infra-observability"
+	generation atomic.Uint64 // bumped by SetMeter so Lazy instruments are recreated
 )
 
 // Counter is a minimal counter helper.
@@ -22,12 +25,18 @@ type Counter struct {
 	counter metric.Int64Counter
 }
 
-// SetMeter sets the meter used by NewCounter. Must be called from observability Init
-// after the global MeterProvider is set (if any). If never set, NewCounter uses a noop meter.
+// SetMeter sets the meter used by NewCounter and Lazy. Called from observability.Init with the
+// meter of the MeterProvider it creates. If never set, NewCounter uses a noop meter.
 func SetMeter(m metric.Meter) {
 	mu.Lock()
 	defer mu.Unlock()
 	meter = m
+	generation.Add(1)
+}
+
+// Meter returns the meter set via SetMeter, or a noop meter before Init.
+func Meter() metric.Meter {
+	return getMeter()
 }
 
 func getMeter() metric.Meter {
@@ -0,0 +1,104 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+	"github.com/MH-Cognition/mhc-infra-observability/metrics"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/credentials"
+)
+
+// meterName is the instrumentation scope of the meter handed to metrics.SetMeter.
+const meterName = "mhc-infra-observability"
+
+// initMetrics creates the MeterProvider with a periodic reader for the exporter selected by
+// cfg.MetricsExporter (OTLP over gRPC or HTTP/protobuf per cfg.Metrics, console, file or none),
+// or with readers instead when given (WithMetricReader), and sets the meter used by the metrics
+// package. The provider lives here rather than in metrics so that instrumentation importing
+// metrics only depends on the OTel metric API.
+// Export interval honours OTEL_METRIC_EXPORT_INTERVAL (SDK default 60s). Returns a shutdown function;
+// after shutdown instruments fall back to the noop meter.
+func initMetrics(ctx context.Context, res *resource.Resource, cfg *config.Config, readers []sdkmetric.Reader) (func(context.Context) error, error) {
+	if len(readers) == 0 {
+		exporter, err := newMetricExporter(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("create %s metric exporter: %w", cfg.MetricsExporter, err)
+		}
+		// OTEL_METRICS_EXPORTER=none leaves the provider without readers: instruments record nothing.
+		if exporter != nil {
+			readers = []sdkmetric.Reader{sdkmetric.NewPeriodicReader(exporter)}
+		}
+	}
+
+	mpOpts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	for _, r := range readers {
+		mpOpts = append(mpOpts, sdkmetric.WithReader(r))
+	}
+	mp := sdkmetric.NewMeterProvider(mpOpts...)
+
+	metrics.SetMeter(mp.Meter(meterName))
+
+	shutdown := func(ctx context.Context) error {
+		metrics.SetMeter(nil)
+		if err := mp.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutdown meter provider: %w", err)
+		}
+		return nil
+	}
+
+	return shutdown, nil
+}
+
+// newOTLPMetricExporter creates the OTLP metric exporter for the configured protocol, transport security,
+// headers and compression.
+func newOTLPMetricExporter(ctx context.Context, o config.OTLP) (sdkmetric.Exporter, error) {
+	switch o.Protocol {
+	case config.ProtocolGRPC:
+		var opts []otlpmetricgrpc.Option
+		if o.HasScheme() {
+			opts = append(opts, otlpmetricgrpc.WithEndpointURL(o.Endpoint))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithEndpoint(o.Endpoint))
+		}
+		if len(o.Headers) > 0 {
+			opts = append(opts, otlpmetricgrpc.WithHeaders(o.Headers))
+		}
+		if o.Compression == config.CompressionGzip {
+			opts = append(opts, otlpmetricgrpc.WithCompressor(config.CompressionGzip))
+		}
+		if o.Secure() {
+			tlsCfg, err := o.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	case config.ProtocolHTTPProtobuf:
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpointURL(o.Endpoint)}
+		if len(o.Headers) > 0 {
+			opts = append(opts, otlpmetrichttp.WithHeaders(o.Headers))
+		}
+		if o.Compression == config.CompressionGzip {
+			opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		}
+		if o.Secure() {
+			tlsCfg, err := o.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", o.Protocol)
+	}
+}
@@ -0,0 +1,131 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newMetricExporter creates the metric exporter selected by cfg.MetricsExporter (OTLP when
+// empty), or nil for "none".
+func newMetricExporter(ctx context.Context, cfg *config.Config) (sdkmetric.Exporter, error) {
+	switch cfg.MetricsExporter {
+	case config.ExporterOTLP, "":
+		return newOTLPMetricExporter(ctx, cfg.Metrics)
+	case config.ExporterConsole:
+		return &metricConsoleExporter{w: os.Stdout}, nil
+	case config.ExporterFile:
+		f, err := os.OpenFile(cfg.ExporterFilePath(config.SignalMetrics), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		exp, err := stdoutmetric.New(stdoutmetric.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &metricFileExporter{Exporter: exp, f: f}, nil
+	case config.ExporterNone:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported metrics exporter %q", cfg.MetricsExporter)
+}
+
+// metricConsoleExporter prints one human-readable line per data point at every export, for local development:
+//
+//	12:04:05 METRIC http.server.request.duration{http.route=/orders/{id}} count=3 sum=0.0371
+type metricConsoleExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (e *metricConsoleExporter) Temporality(k sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(k)
+}
+
+func (e *metricConsoleExporter) Aggregation(k sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(k)
+}
+
+func (e *metricConsoleExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now().Format("15:04:05")
+	var b strings.Builder
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, p := range data.DataPoints {
+					fmt.Fprintf(&b, "%s METRIC %s%s value=%d\n", now, m.Name, formatMetricAttrs(p.Attributes), p.Value)
+				}
+			case metricdata.Sum[float64]:
+				for _, p := range data.DataPoints {
+					fmt.Fprintf(&b, "%s METRIC %s%s value=%g\n", now, m.Name, formatMetricAttrs(p.Attributes), p.Value)
+				}
+			case metricdata.Gauge[int64]:
+				for _, p := range data.DataPoints {
+					fmt.Fprintf(&b, "%s METRIC %s%s value=%d\n", now, m.Name, formatMetricAttrs(p.Attributes), p.Value)
+				}
+			case metricdata.Gauge[float64]:
+				for _, p := range data.DataPoints {
+					fmt.Fprintf(&b, "%s METRIC %s%s value=%g\n", now, m.Name, formatMetricAttrs(p.Attributes), p.Value)
+				}
+			case metricdata.Histogram[int64]:
+				for _, p := range data.DataPoints {
+					fmt.Fprintf(&b, "%s METRIC %s%s count=%d sum=%d\n", now, m.Name, formatMetricAttrs(p.Attributes), p.Count, p.Sum)
+				}
+			case metricdata.Histogram[float64]:
+				for _, p := range data.DataPoints {
+					fmt.Fprintf(&b, "%s METRIC %s%s count=%d sum=%g\n", now, m.Name, formatMetricAttrs(p.Attributes), p.Count, p.Sum)
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *metricConsoleExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *metricConsoleExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func formatMetricAttrs(set attribute.Set) string {
+	if set.Len() == 0 {
+		return ""
+	}
+	parts := make([]string, 0, set.Len())
+	for _, kv := range set.ToSlice() {
+		parts = append(parts, string(kv.Key)+"="+kv.Value.Emit())
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// metricFileExporter closes the JSON-lines file on shutdown.
+type metricFileExporter struct {
+	sdkmetric.Exporter
+	f *os.File
+}
+
+func (e *metricFileExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if cerr := e.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -5,6 +5,7 @@ package observability
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -17,17 +18,50 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
 )
 
-// Init initializes the observability stack (tracing, propagator). Uses the single Resource
+// Init initializes the observability stack (tracing, metrics, OTLP logs when enabled, propagator). Uses the single Resource
 // created by the service via NewResource. The service must call NewResource once and pass
 // the same res to Init; do not create resources elsewhere.
+// Options (WithSampler, WithExporter, WithMetricReader, ...) override parts of the env-driven setup.
+// With cfg.Disabled (OTEL_SDK_DISABLED=true) Init installs nothing and ignores opts; StartSpan,
+// NewCounter and the middleware then run against noop providers, and Logger still logs to stdout.
 // Returns a shutdown function that must be called before process exit (e.g., in main's defer).
-func Init(ctx context.Context, res *resource.Resource, cfg *config.Config) (func(context.Context) error, error) {
-	shutdown, err := tracing.Init(ctx, res, cfg)
+func Init(ctx context.Context, res *resource.Resource, cfg *config.Config, opts ...Option) (func(context.Context) error, error) {
+	if cfg.Disabled {
+		// Nothing is installed: Tracer and the meter stay noop, Logger writes stdout JSON.
+		return func(context.Context) error { return nil }, nil
+	}
+
+	o := newOptions(opts)
+
+	shutdownTracing, err := tracing.Init(ctx, res, cfg, o.tracing...)
 	if err != nil {
 		return nil, fmt.Errorf("init tracing: %w", err)
 	}
+
+	shutdownMetrics, err := initMetrics(ctx, res, cfg, o.metricReaders)
+	if err != nil {
+		_ = shutdownTracing(ctx)
+		return nil, fmt.Errorf("init metrics: %w", err)
+	}
+
+	shutdownLogging, err := logging.Init(ctx, res, cfg, o.logging...)
+	if err != nil {
+		_ = shutdownMetrics(ctx)
+		_ = shutdownTracing(ctx)
+		return nil, fmt.Errorf("init logging: %w", err)
+	}
+
+	// Shut down in reverse init order; every provider is flushed even if one fails.
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(
+			shutdownLogging(ctx),
+			shutdownMetrics(ctx),
+			shutdownTracing(ctx),
+		)
+	}
 	return shutdown, nil
 }
 
@@ -65,9 +99,16 @@ func HTTPMiddleware(next http.Handler) http.Handler {
 	return tracing.Middleware(next)
 }
 
+// NewHTTPMiddleware returns net/http middleware like HTTPMiddleware, configured with opts
+// (e.g., HTTPIgnorePaths("/health", "/ready") to leave probes untraced).
+func NewHTTPMiddleware(opts ...InstrumentationOption) func(http.Handler) http.Handler {
+	return tracing.NewMiddleware(opts...)
+}
+
 // GrpcServerInterceptor returns a gRPC unary server interceptor for trace propagation.
-func GrpcServerInterceptor() grpc.UnaryServerInterceptor {
-	return tracing.UnaryServerInterceptor()
+// gRPC health checks are never traced; opts can exclude further methods.
+func GrpcServerInterceptor(opts ...InstrumentationOption) grpc.UnaryServerInterceptor {
+	return tracing.UnaryServerInterceptor(opts...)
 }
 
 // GrpcClientInterceptor returns a gRPC unary client interceptor for trace propagation.
@@ -75,6 +116,43 @@ func GrpcClientInterceptor() grpc.UnaryClientInterceptor {
 	return tracing.UnaryClientInterceptor()
 }
 
+// HTTPClient returns an http.Client whose requests get client spans, trace context headers and
+// the http.client.request.duration metric. Use it (or HTTPTransport) instead of injecting by hand.
+func HTTPClient() *http.Client {
+	return &http.Client{Transport: tracing.NewTransport(nil)}
+}
+
+// HTTPTransport wraps base (http.DefaultTransport when nil) with the same tracing as HTTPClient,
+// for services that build their own http.Client.
+func HTTPTransport(base http.RoundTripper) http.RoundTripper {
+	return tracing.NewTransport(base)
+}
+
+// GrpcStreamServerInterceptor returns a gRPC stream server interceptor: one span per stream,
+// with an event per message. gRPC health checks are never traced; opts can exclude further methods.
+func GrpcStreamServerInterceptor(opts ...InstrumentationOption) grpc.StreamServerInterceptor {
+	return tracing.StreamServerInterceptor(opts...)
+}
+
+// GrpcStreamClientInterceptor returns a gRPC stream client interceptor for trace propagation:
+// one span per stream, with an event per message.
+func GrpcStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return tracing.StreamClientInterceptor()
+}
+
+// GrpcServerStatsHandler returns a gRPC stats handler for grpc.StatsHandler: a span per RPC
+// (unary and streaming) plus rpc.server.duration and message size metrics. Use it instead of
+// the server interceptors, not alongside them. opts filter methods as for GrpcServerInterceptor.
+func GrpcServerStatsHandler(opts ...InstrumentationOption) stats.Handler {
+	return tracing.NewServerStatsHandler(opts...)
+}
+
+// GrpcClientStatsHandler returns a gRPC stats handler for grpc.WithStatsHandler: a span per RPC
+// plus rpc.client.duration and message size metrics. Use it instead of the client interceptors.
+func GrpcClientStatsHandler() stats.Handler {
+	return tracing.NewClientStatsHandler()
+}
+
 // InjectHTTPRequest injects trace context into outgoing HTTP request headers.
 func InjectHTTPRequest(ctx context.Context, req *http.Request) {
 	tracing.InjectIntoRequest(ctx, req)
@@ -100,6 +178,54 @@ func StartKafkaProducerSpan(ctx context.Context, topic string) (context.Context,
 	return tracing.StartKafkaProducerSpan(ctx, topic)
 }
 
+// KafkaProducerMessage describes a record about to be produced (topic, key, body size).
+type KafkaProducerMessage = tracing.KafkaProducerMessage
+
+// KafkaConsumerMessage describes a consumed record (topic, partition, offset, consumer group,
+// key, body size and headers).
+type KafkaConsumerMessage = tracing.KafkaConsumerMessage
+
+// StartKafkaProducerMessageSpan starts a producer span for msg. Use returned ctx with
+// InjectKafkaHeaders and call RecordKafkaProduceResult when the broker acknowledges the record.
+func StartKafkaProducerMessageSpan(ctx context.Context, msg KafkaProducerMessage) (context.Context, trace.Span) {
+	return tracing.StartKafkaProducerMessageSpan(ctx, msg)
+}
+
+// RecordKafkaProduceResult records the partition and offset a record was written to, or the
+// produce error, on the producer span.
+func RecordKafkaProduceResult(span trace.Span, partition int32, offset int64, err error) {
+	tracing.RecordKafkaProduceResult(span, partition, offset, err)
+}
+
+// StartKafkaConsumerMessageSpan starts a span for processing msg, continuing the trace from
+// msg.Headers when set.
+func StartKafkaConsumerMessageSpan(ctx context.Context, msg KafkaConsumerMessage) (context.Context, trace.Span) {
+	return tracing.StartKafkaConsumerMessageSpan(ctx, msg)
+}
+
+// StartKafkaBatchSpan starts a span for processing a polled batch of messages from topic, linked
+// to every message's producer context.
+func StartKafkaBatchSpan(ctx context.Context, topic string, messages []KafkaConsumerMessage) (context.Context, trace.Span) {
+	return tracing.StartKafkaBatchSpan(ctx, topic, messages)
+}
+
+// StartKafkaBatchMessageSpan starts a span for one message of a batch, as a child of the batch
+// span in ctx linked to the message's producer context. It records no metrics; the batch span does.
+func StartKafkaBatchMessageSpan(ctx context.Context, msg KafkaConsumerMessage) (context.Context, trace.Span) {
+	return tracing.StartKafkaBatchMessageSpan(ctx, msg)
+}
+
+// RecordKafkaConsumerLag sets the lag of a consumer group on a topic partition, reported by the
+// messaging.kafka.consumer.lag gauge until ForgetKafkaConsumerLag.
+func RecordKafkaConsumerLag(topic string, partition int32, group string, lag int64) {
+	tracing.RecordKafkaConsumerLag(topic, partition, group, lag)
+}
+
+// ForgetKafkaConsumerLag stops reporting the lag of a partition (e.g., after a rebalance revoked it).
+func ForgetKafkaConsumerLag(topic string, partition int32, group string) {
+	tracing.ForgetKafkaConsumerLag(topic, partition, group)
+}
+
 // NewCounter creates a basic counter for metrics. Name and description identify the metric.
 func NewCounter(name, description string) (*metrics.Counter, error) {
 	return metrics.NewCounter(name, description)
@@ -0,0 +1,105 @@
+package observability
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/MH-Cognition/mhc-infra-observability/logging"
+	"github.com/MH-Cognition/mhc-infra-observability/tracing"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Option customizes Init. With no options Init behaves exactly as configured by env.
+type Option func(*options)
+
+type options struct {
+	tracing       []tracing.InitOption
+	metricReaders []sdkmetric.Reader
+	logging       []logging.InitOption
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithSampler replaces the trace sampler configured via OTEL_TRACES_SAMPLER.
+func WithSampler(s sdktrace.Sampler) Option {
+	return func(o *options) {
+		o.tracing = append(o.tracing, tracing.WithSampler(s))
+	}
+}
+
+// WithSpanProcessor registers an additional span processor alongside the exporter.
+// May be given multiple times.
+func WithSpanProcessor(p sdktrace.SpanProcessor) Option {
+	return func(o *options) {
+		o.tracing = append(o.tracing, tracing.WithSpanProcessor(p))
+	}
+}
+
+// WithExporter replaces the OTLP span exporter (spans are still batched).
+func WithExporter(e sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.tracing = append(o.tracing, tracing.WithExporter(e))
+	}
+}
+
+// WithPropagators replaces the trace context propagator used by the HTTP, gRPC and Kafka helpers.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(o *options) {
+		o.tracing = append(o.tracing, tracing.WithPropagator(p))
+	}
+}
+
+// WithMetricReader replaces the periodic OTLP metric reader. May be given multiple times.
+func WithMetricReader(r sdkmetric.Reader) Option {
+	return func(o *options) {
+		o.metricReaders = append(o.metricReaders, r)
+	}
+}
+
+// WithLogHandler replaces the JSON-to-stdout handler behind Logger. Records still carry
+// trace_id/span_id and are still exported over OTLP when OTEL_LOGS_EXPORTER=otlp.
+func WithLogHandler(h slog.Handler) Option {
+	return func(o *options) {
+		o.logging = append(o.logging, logging.WithHandler(h))
+	}
+}
+
+// InstrumentationOption configures the HTTP middleware and gRPC server interceptors.
+type InstrumentationOption = tracing.Option
+
+// HTTPIgnorePaths leaves HTTP requests whose path starts with any prefix untraced
+// (e.g., "/health", "/ready", "/metrics").
+func HTTPIgnorePaths(prefixes ...string) InstrumentationOption {
+	return tracing.WithIgnoredPaths(prefixes...)
+}
+
+// HTTPFilter sets a predicate that returns false for requests that must not be traced.
+func HTTPFilter(f func(*http.Request) bool) InstrumentationOption {
+	return tracing.WithHTTPFilter(f)
+}
+
+// GrpcIgnoreMethods leaves gRPC calls whose full method ("/pkg.Service/Method") starts with
+// any prefix untraced. The gRPC health service is always ignored.
+func GrpcIgnoreMethods(prefixes ...string) InstrumentationOption {
+	return tracing.WithIgnoredMethods(prefixes...)
+}
+
+// GrpcFilter sets a predicate that returns false for gRPC full methods that must not be traced.
+func GrpcFilter(f func(fullMethod string) bool) InstrumentationOption {
+	return tracing.WithGrpcFilter(f)
+}
+
+// HTTPRouteResolver sets a function returning the route template ("/orders/{id}") for a request,
+// used for the span name and http.route when the service does not route with http.ServeMux.
+func HTTPRouteResolver(f func(*http.Request) string) InstrumentationOption {
+	return tracing.WithRouteResolver(f)
+}
@@ -5,6 +5,10 @@ package observability
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
 
 	"github.com/MH-Cognition/mhc-infra-observability/config"
 
@@ -16,10 +20,34 @@ import (
 // NewResource creates the single OpenTelemetry Resource for this process.
 // Use ONE schema version only. Must be called once; pass the result to Init.
 // Do not call resource.New, resource.Default, or resource.Merge anywhere else.
+//
+// Attributes are layered, later ones winning: detector results (cfg.ResourceDetectors), then
+// cfg.ResourceAttributes (OTEL_RESOURCE_ATTRIBUTES and the config file), then service.name,
+// service.version and deployment.environment from the dedicated Config fields when non-empty.
+// config.Load fills those fields from the resource attributes when their env vars are unset, so
+// OTEL_SERVICE_NAME wins over service.name only when it is set. Only the attributes of the SDK
+// detectors are used, never their Resources, so the result always carries our single schema URL. A detector that finds only part of its data (e.g., no container ID
+// outside a container) contributes what it found.
 func NewResource(ctx context.Context, cfg *config.Config) (*resource.Resource, error) {
-	attrs := []attribute.KeyValue{
-		semconv.ServiceName(cfg.ServiceName),
-		semconv.DeploymentEnvironment(cfg.Environment),
+	attrs, err := detect(ctx, cfg.ResourceDetectors)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(cfg.ResourceAttributes))
+	for k := range cfg.ResourceAttributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs = append(attrs, attribute.String(k, cfg.ResourceAttributes[k]))
+	}
+
+	if cfg.ServiceName != "" {
+		attrs = append(attrs, semconv.ServiceName(cfg.ServiceName))
+	}
+	if cfg.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(cfg.Environment))
 	}
 	if cfg.ServiceVersion != "" {
 		attrs = append(attrs, semconv.ServiceVersion(cfg.ServiceVersion))
@@ -29,3 +57,63 @@ func NewResource(ctx context.Context, cfg *config.Config) (*resource.Resource, e
 		resource.WithAttributes(attrs...),
 	)
 }
+
+// detect runs the named detectors and returns their attributes.
+func detect(ctx context.Context, detectors []string) ([]attribute.KeyValue, error) {
+	var opts []resource.Option
+	var attrs []attribute.KeyValue
+	for _, d := range detectors {
+		switch d {
+		case config.DetectorHost:
+			opts = append(opts, resource.WithHost(), resource.WithHostID())
+		case config.DetectorProcess:
+			opts = append(opts, resource.WithProcess())
+		case config.DetectorOS:
+			opts = append(opts, resource.WithOS())
+		case config.DetectorContainer:
+			opts = append(opts, resource.WithContainer())
+		case config.DetectorK8s:
+			attrs = append(attrs, k8sAttributes()...)
+		default:
+			return nil, fmt.Errorf("unknown resource detector %q", d)
+		}
+	}
+	if len(opts) == 0 {
+		return attrs, nil
+	}
+
+	detected, err := resource.New(ctx, opts...)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("detect resource: %w", err)
+	}
+	if detected != nil {
+		attrs = append(detected.Attributes(), attrs...)
+	}
+	return attrs, nil
+}
+
+// k8sEnv maps the env vars a pod spec sets from the Kubernetes downward API (fieldRef
+// metadata.name, metadata.uid, metadata.namespace, spec.nodeName) or by hand to resource attributes.
+var k8sEnv = []struct {
+	env  string
+	attr func(string) attribute.KeyValue
+}{
+	{"K8S_POD_NAME", semconv.K8SPodName},
+	{"K8S_POD_UID", semconv.K8SPodUID},
+	{"K8S_NAMESPACE_NAME", semconv.K8SNamespaceName},
+	{"K8S_NODE_NAME", semconv.K8SNodeName},
+	{"K8S_CONTAINER_NAME", semconv.K8SContainerName},
+	{"K8S_DEPLOYMENT_NAME", semconv.K8SDeploymentName},
+	{"K8S_CLUSTER_NAME", semconv.K8SClusterName},
+}
+
+// k8sAttributes returns the k8s.* attributes for the K8S_* env vars that are set.
+func k8sAttributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for _, e := range k8sEnv {
+		if v := os.Getenv(e.env); v != "" {
+			attrs = append(attrs, e.attr(v))
+		}
+	}
+	return attrs
+}
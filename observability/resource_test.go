@@ -0,0 +1,144 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// resourceEnv lists the variables NewResource depends on through config.Load and the k8s detector.
+var resourceEnv = []string{
+	"OTEL_CONFIG_FILE", "OTEL_SERVICE_NAME", "OTEL_SERVICE_VERSION", "OTEL_ENVIRONMENT",
+	"OTEL_RESOURCE_ATTRIBUTES", "OTEL_RESOURCE_DETECTORS",
+	"K8S_POD_NAME", "K8S_POD_UID", "K8S_NAMESPACE_NAME", "K8S_NODE_NAME",
+	"K8S_CONTAINER_NAME", "K8S_DEPLOYMENT_NAME", "K8S_CLUSTER_NAME",
+}
+
+func loadResource(t *testing.T, env map[string]string) map[attribute.Key]string {
+	t.Helper()
+	for _, k := range resourceEnv {
+		t.Setenv(k, "")
+	}
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+	res, err := NewResource(context.Background(), config.Load())
+	if err != nil {
+		t.Fatalf("NewResource: %v", err)
+	}
+	attrs := map[attribute.Key]string{}
+	for _, kv := range res.Attributes() {
+		attrs[kv.Key] = kv.Value.Emit()
+	}
+	return attrs
+}
+
+func TestNewResourcePrecedence(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want map[attribute.Key]string // "" means the attribute must be absent
+	}{
+		{
+			name: "defaults",
+			want: map[attribute.Key]string{
+				"service.name":           config.DefaultServiceName,
+				"deployment.environment": config.EnvironmentDevelopment,
+				"service.version":        "",
+			},
+		},
+		{
+			name: "service.name from OTEL_RESOURCE_ATTRIBUTES when OTEL_SERVICE_NAME is unset",
+			env:  map[string]string{"OTEL_RESOURCE_ATTRIBUTES": "service.name=foo,deployment.environment=staging,team=lms"},
+			want: map[attribute.Key]string{
+				"service.name":           "foo",
+				"deployment.environment": "staging",
+				"team":                   "lms",
+			},
+		},
+		{
+			name: "OTEL_SERVICE_NAME wins when set",
+			env: map[string]string{
+				"OTEL_RESOURCE_ATTRIBUTES": "service.name=foo,service.version=1",
+				"OTEL_SERVICE_NAME":        "orders",
+				"OTEL_SERVICE_VERSION":     "2",
+			},
+			want: map[attribute.Key]string{"service.name": "orders", "service.version": "2"},
+		},
+		{
+			name: "resource attributes win over detectors",
+			env: map[string]string{
+				"OTEL_RESOURCE_DETECTORS":  "k8s",
+				"K8S_POD_NAME":             "pod-a",
+				"K8S_NAMESPACE_NAME":       "lms",
+				"OTEL_RESOURCE_ATTRIBUTES": "k8s.pod.name=override",
+			},
+			want: map[attribute.Key]string{"k8s.pod.name": "override", "k8s.namespace.name": "lms"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := loadResource(t, tt.env)
+			for k, want := range tt.want {
+				if got, ok := attrs[k]; want == "" && ok {
+					t.Errorf("%s = %q, want absent", k, got)
+				} else if want != "" && got != want {
+					t.Errorf("%s = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestK8sDetector(t *testing.T) {
+	attrs := loadResource(t, map[string]string{
+		"OTEL_RESOURCE_DETECTORS": "k8s",
+		"K8S_POD_NAME":            "orders-7d9f",
+		"K8S_POD_UID":             "0b5c",
+		"K8S_NAMESPACE_NAME":      "lms",
+		"K8S_NODE_NAME":           "node-1",
+		"K8S_CONTAINER_NAME":      "app",
+		"K8S_DEPLOYMENT_NAME":     "orders",
+		"K8S_CLUSTER_NAME":        "eu-west",
+	})
+	want := map[attribute.Key]string{
+		"k8s.pod.name":        "orders-7d9f",
+		"k8s.pod.uid":         "0b5c",
+		"k8s.namespace.name":  "lms",
+		"k8s.node.name":       "node-1",
+		"k8s.container.name":  "app",
+		"k8s.deployment.name": "orders",
+		"k8s.cluster.name":    "eu-west",
+	}
+	for k, v := range want {
+		if attrs[k] != v {
+			t.Errorf("%s = %q, want %q", k, attrs[k], v)
+		}
+	}
+
+	t.Run("unset variables are skipped", func(t *testing.T) {
+		attrs := loadResource(t, map[string]string{"OTEL_RESOURCE_DETECTORS": "k8s", "K8S_POD_NAME": "orders-7d9f"})
+		for k := range want {
+			if _, ok := attrs[k]; ok != (k == "k8s.pod.name") {
+				t.Errorf("%s present = %v", k, ok)
+			}
+		}
+	})
+
+	t.Run("not run unless listed", func(t *testing.T) {
+		attrs := loadResource(t, map[string]string{"K8S_POD_NAME": "orders-7d9f"})
+		if _, ok := attrs["k8s.pod.name"]; ok {
+			t.Error("k8s.pod.name set without the k8s detector")
+		}
+	})
+}
+
+func TestNewResourceUnknownDetector(t *testing.T) {
+	cfg := &config.Config{ServiceName: "orders", ResourceDetectors: []string{"gcp"}}
+	if _, err := NewResource(context.Background(), cfg); err == nil {
+		t.Error("NewResource with an unknown detector: want error")
+	}
+}
@@ -0,0 +1,301 @@
+// Package observabilitytest initializes the observability stack in memory for unit tests, so
+// services can assert the spans, logs and metrics their code produces: create a Recorder with
+// New, run the code under test, then check its telemetry with AssertSpan, FindLog and MetricValue.
+//
+// The stack is process-global, so tests using a Recorder must not run in parallel.
+package observabilitytest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+	"github.com/MH-Cognition/mhc-infra-observability/observability"
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Recorder captures everything the observability stack emits during a test.
+type Recorder struct {
+	tb       testing.TB
+	spans    *tracetest.InMemoryExporter
+	reader   *sdkmetric.ManualReader
+	logs     *logStore
+	shutdown func(context.Context) error
+}
+
+// LogRecord is a captured log record. Attrs holds every attribute by key, including those added
+// with With and the trace_id/span_id added from ctx; keys inside groups are dot-joined ("req.id").
+type LogRecord struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]any
+}
+
+// New initializes the observability stack against in-memory sinks: every span is sampled and
+// recorded synchronously, metrics are read on demand and logs are captured instead of written
+// to stdout. The stack is shut down when the test ends.
+func New(tb testing.TB) *Recorder {
+	tb.Helper()
+	r := &Recorder{tb: tb}
+	r.init()
+	tb.Cleanup(func() {
+		if err := r.shutdown(context.Background()); err != nil {
+			tb.Errorf("observabilitytest: shutdown: %v", err)
+		}
+	})
+	return r
+}
+
+func (r *Recorder) init() {
+	r.tb.Helper()
+	r.spans = tracetest.NewInMemoryExporter()
+	r.reader = sdkmetric.NewManualReader()
+	r.logs = &logStore{}
+
+	cfg := &config.Config{
+		ServiceName:  "observabilitytest",
+		Environment:  "test",
+		Propagators:  []string{propagation.NameTraceContext, propagation.NameBaggage},
+		LogsExporter: "none",
+	}
+	res, err := observability.NewResource(context.Background(), cfg)
+	if err != nil {
+		r.tb.Fatalf("observabilitytest: resource: %v", err)
+	}
+	shutdown, err := observability.Init(context.Background(), res, cfg,
+		observability.WithSampler(sdktrace.AlwaysSample()),
+		observability.WithExporter(tracetest.NewNoopExporter()),
+		observability.WithSpanProcessor(sdktrace.NewSimpleSpanProcessor(r.spans)),
+		observability.WithMetricReader(r.reader),
+		observability.WithLogHandler(&captureHandler{store: r.logs}),
+	)
+	if err != nil {
+		r.tb.Fatalf("observabilitytest: init: %v", err)
+	}
+	r.shutdown = shutdown
+}
+
+// Reset discards all recorded spans, logs and metrics by reinitializing the stack. Use it between
+// subtests sharing one Recorder; instruments created by middleware and interceptors pick up the
+// new stack automatically.
+func (r *Recorder) Reset() {
+	r.tb.Helper()
+	if err := r.shutdown(context.Background()); err != nil {
+		r.tb.Errorf("observabilitytest: shutdown: %v", err)
+	}
+	r.init()
+}
+
+// Spans returns the spans ended so far, in end order.
+func (r *Recorder) Spans() tracetest.SpanStubs {
+	return r.spans.GetSpans()
+}
+
+// FindSpan returns the first ended span with the given name that has all attrs.
+func (r *Recorder) FindSpan(name string, attrs ...attribute.KeyValue) (tracetest.SpanStub, bool) {
+	for _, s := range r.spans.GetSpans() {
+		if s.Name == name && hasAttrs(s.Attributes, attrs) {
+			return s, true
+		}
+	}
+	return tracetest.SpanStub{}, false
+}
+
+// AssertSpan fails the test unless a span with the given name and all attrs has ended, and
+// returns it. The failure message lists the spans that were recorded.
+func (r *Recorder) AssertSpan(name string, attrs ...attribute.KeyValue) tracetest.SpanStub {
+	r.tb.Helper()
+	s, ok := r.FindSpan(name, attrs...)
+	if !ok {
+		r.tb.Errorf("observabilitytest: no span %q with %v; recorded:\n%s", name, attrs, describeSpans(r.spans.GetSpans()))
+	}
+	return s
+}
+
+// Logs returns the captured log records, oldest first.
+func (r *Recorder) Logs() []LogRecord {
+	return r.logs.all()
+}
+
+// FindLog returns the first captured log record with the given message.
+func (r *Recorder) FindLog(msg string) (LogRecord, bool) {
+	for _, l := range r.logs.all() {
+		if l.Message == msg {
+			return l, true
+		}
+	}
+	return LogRecord{}, false
+}
+
+// MetricValue returns the current value of the named metric, summed over the data points that
+// carry all attrs: the total of a counter or up-down counter, the last value of a gauge, or the
+// number of recorded observations of a histogram. ok is false when no such data point exists.
+func (r *Recorder) MetricValue(name string, attrs ...attribute.KeyValue) (value float64, ok bool) {
+	r.tb.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := r.reader.Collect(context.Background(), &rm); err != nil {
+		r.tb.Fatalf("observabilitytest: collect metrics: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				return sumPoints(data.DataPoints, attrs)
+			case metricdata.Sum[float64]:
+				return sumPoints(data.DataPoints, attrs)
+			case metricdata.Gauge[int64]:
+				return sumPoints(data.DataPoints, attrs)
+			case metricdata.Gauge[float64]:
+				return sumPoints(data.DataPoints, attrs)
+			case metricdata.Histogram[int64]:
+				return countPoints(data.DataPoints, attrs)
+			case metricdata.Histogram[float64]:
+				return countPoints(data.DataPoints, attrs)
+			}
+		}
+	}
+	return 0, false
+}
+
+func sumPoints[N int64 | float64](points []metricdata.DataPoint[N], attrs []attribute.KeyValue) (float64, bool) {
+	var total float64
+	found := false
+	for _, p := range points {
+		if hasAttrs(p.Attributes.ToSlice(), attrs) {
+			total += float64(p.Value)
+			found = true
+		}
+	}
+	return total, found
+}
+
+func countPoints[N int64 | float64](points []metricdata.HistogramDataPoint[N], attrs []attribute.KeyValue) (float64, bool) {
+	var total float64
+	found := false
+	for _, p := range points {
+		if hasAttrs(p.Attributes.ToSlice(), attrs) {
+			total += float64(p.Count)
+			found = true
+		}
+	}
+	return total, found
+}
+
+// hasAttrs reports whether have contains every attribute in want.
+func hasAttrs(have, want []attribute.KeyValue) bool {
+	for _, w := range want {
+		if !slices.ContainsFunc(have, func(h attribute.KeyValue) bool { return h == w }) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeSpans(spans tracetest.SpanStubs) string {
+	if len(spans) == 0 {
+		return "  (none)"
+	}
+	var b strings.Builder
+	for _, s := range spans {
+		fmt.Fprintf(&b, "  %q %v\n", s.Name, s.Attributes)
+	}
+	return b.String()
+}
+
+// logStore is shared by a captureHandler and every handler derived from it via WithAttrs/WithGroup.
+type logStore struct {
+	mu      sync.Mutex
+	records []LogRecord
+}
+
+func (s *logStore) add(l LogRecord) {
+	s.mu.Lock()
+	s.records = append(s.records, l)
+	s.mu.Unlock()
+}
+
+func (s *logStore) all() []LogRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.records)
+}
+
+// captureHandler is a slog.Handler that stores records in memory, at every level.
+type captureHandler struct {
+	store  *logStore
+	attrs  []slog.Attr
+	prefix string
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *captureHandler) Handle(_ context.Context, rec slog.Record) error {
+	l := LogRecord{Time: rec.Time, Level: rec.Level, Message: rec.Message, Attrs: map[string]any{}}
+	for _, a := range h.attrs {
+		addAttr(l.Attrs, "", a)
+	}
+	rec.Attrs(func(a slog.Attr) bool {
+		addAttr(l.Attrs, h.prefix, a)
+		return true
+	})
+	h.store.add(l)
+	return nil
+}
+
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = slices.Clone(h.attrs)
+	for _, a := range attrs {
+		if h.prefix != "" {
+			a.Key = h.prefix + a.Key
+		}
+		next.attrs = append(next.attrs, a)
+	}
+	return &next
+}
+
+func (h *captureHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	next := *h
+	next.prefix = h.prefix + name + "."
+	return &next
+}
+
+// addAttr stores a under prefix+key, flattening groups into dot-joined keys.
+func addAttr(dst map[string]any, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		p := prefix
+		if a.Key != "" {
+			p += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			addAttr(dst, p, ga)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	dst[prefix+a.Key] = v.Any()
+}
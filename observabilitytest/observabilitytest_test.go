@@ -0,0 +1,260 @@
+package observabilitytest_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MH-Cognition/mhc-infra-observability/metrics"
+	"github.com/MH-Cognition/mhc-infra-observability/observability"
+	"github.com/MH-Cognition/mhc-infra-observability/observabilitytest"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func newOrderMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /orders", func(w http.ResponseWriter, r *http.Request) {
+		observability.Logger(r.Context()).Info(r.Context(), "order created")
+		w.WriteHeader(http.StatusCreated)
+	})
+	return mux
+}
+
+// TestCreateOrder is what a service test looks like: run the handler, then assert its telemetry.
+func TestCreateOrder(t *testing.T) {
+	rec := observabilitytest.New(t)
+	handler := observability.HTTPMiddleware(newOrderMux())
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/orders", strings.NewReader("{}")))
+
+	rec.AssertSpan("http.POST /orders", attribute.Int("http.status_code", 201))
+	if _, ok := rec.FindLog("order created"); !ok {
+		t.Error("missing log")
+	}
+	if v, _ := rec.MetricValue("http.server.request.duration"); v != 1 {
+		t.Errorf("requests = %v", v)
+	}
+}
+
+// errorTB records Errorf calls instead of failing the test, so failure output can be checked.
+type errorTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *errorTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSpanFailureOutput(t *testing.T) {
+	tests := []struct {
+		name  string
+		spans []string
+		find  string
+		attrs []attribute.KeyValue
+		want  []string // substrings of the failure message; nil means no failure
+	}{
+		{
+			name:  "found",
+			spans: []string{"checkout"},
+			find:  "checkout",
+			attrs: []attribute.KeyValue{attribute.String("order.id", "42")},
+		},
+		{
+			name: "no spans",
+			find: "checkout",
+			want: []string{`no span "checkout"`, "(none)"},
+		},
+		{
+			name:  "wrong name",
+			spans: []string{"payment"},
+			find:  "checkout",
+			want:  []string{`no span "checkout"`, `"payment"`, "order.id"},
+		},
+		{
+			name:  "wrong attribute",
+			spans: []string{"checkout"},
+			find:  "checkout",
+			attrs: []attribute.KeyValue{attribute.String("order.id", "7")},
+			want:  []string{`no span "checkout"`, "order.id", `"checkout"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &errorTB{TB: t}
+			rec := observabilitytest.New(tb)
+			for _, name := range tt.spans {
+				_, span := observability.StartSpan(context.Background(), name)
+				span.SetAttributes(attribute.String("order.id", "42"))
+				span.End()
+			}
+
+			rec.AssertSpan(tt.find, tt.attrs...)
+
+			if tt.want == nil {
+				if len(tb.errors) != 0 {
+					t.Fatalf("unexpected failure: %v", tb.errors)
+				}
+				return
+			}
+			if len(tb.errors) != 1 {
+				t.Fatalf("got %d failures, want 1: %v", len(tb.errors), tb.errors)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(tb.errors[0], w) {
+					t.Errorf("failure %q does not contain %q", tb.errors[0], w)
+				}
+			}
+		})
+	}
+}
+
+func TestFindLogGroupedAttrs(t *testing.T) {
+	rec := observabilitytest.New(t)
+	ctx := context.Background()
+	logger := observability.Logger(ctx).WithTrace(ctx)
+
+	logger.With("service", "orders").
+		WithGroup("req").
+		With("id", 7).
+		WithGroup("user").
+		Info("grouped", "name", "bob", slog.Group("plan", "tier", "pro"))
+	logger.Info("top level", slog.Group("db", "table", "orders"), slog.Group("", "inline", true))
+
+	tests := []struct {
+		msg  string
+		want map[string]any
+	}{
+		{
+			msg: "grouped",
+			want: map[string]any{
+				"service":            "orders",
+				"req.id":             int64(7),
+				"req.user.name":      "bob",
+				"req.user.plan.tier": "pro",
+			},
+		},
+		{
+			msg: "top level",
+			want: map[string]any{
+				"db.table": "orders",
+				"inline":   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			l, ok := rec.FindLog(tt.msg)
+			if !ok {
+				t.Fatalf("log %q not found in %v", tt.msg, rec.Logs())
+			}
+			if len(l.Attrs) != len(tt.want) {
+				t.Errorf("attrs = %v, want %v", l.Attrs, tt.want)
+			}
+			for k, v := range tt.want {
+				if l.Attrs[k] != v {
+					t.Errorf("attrs[%q] = %v (%T), want %v (%T)", k, l.Attrs[k], l.Attrs[k], v, v)
+				}
+			}
+		})
+	}
+
+	if _, ok := rec.FindLog("never logged"); ok {
+		t.Error("FindLog found a message that was not logged")
+	}
+}
+
+type testInstruments struct {
+	gauge     metric.Int64Gauge
+	histogram metric.Float64Histogram
+}
+
+var testMetrics = metrics.NewLazy(func(m metric.Meter) testInstruments {
+	gauge, _ := m.Int64Gauge("test.queue.depth")
+	histogram, _ := m.Float64Histogram("test.latency")
+	return testInstruments{gauge: gauge, histogram: histogram}
+})
+
+func TestMetricValue(t *testing.T) {
+	rec := observabilitytest.New(t)
+	ctx := context.Background()
+	a := metric.WithAttributes(attribute.String("queue", "a"))
+	b := metric.WithAttributes(attribute.String("queue", "b"))
+
+	counter, err := observability.NewCounter("test.orders", "orders created")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(ctx, 2, a)
+	counter.Add(ctx, 3, b)
+
+	ins := testMetrics.Get()
+	ins.gauge.Record(ctx, 10, a)
+	ins.gauge.Record(ctx, 4, a)
+	ins.histogram.Record(ctx, 0.5, a)
+	ins.histogram.Record(ctx, 1.5, a)
+	ins.histogram.Record(ctx, 9, b)
+
+	tests := []struct {
+		name   string
+		metric string
+		attrs  []attribute.KeyValue
+		want   float64
+		wantOK bool
+	}{
+		{name: "counter total", metric: "test.orders", want: 5, wantOK: true},
+		{name: "counter by attribute", metric: "test.orders", attrs: []attribute.KeyValue{attribute.String("queue", "b")}, want: 3, wantOK: true},
+		{name: "gauge last value", metric: "test.queue.depth", want: 4, wantOK: true},
+		{name: "histogram count", metric: "test.latency", want: 3, wantOK: true},
+		{name: "histogram count by attribute", metric: "test.latency", attrs: []attribute.KeyValue{attribute.String("queue", "a")}, want: 2, wantOK: true},
+		{name: "no matching attribute", metric: "test.orders", attrs: []attribute.KeyValue{attribute.String("queue", "c")}},
+		{name: "unknown metric", metric: "test.unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rec.MetricValue(tt.metric, tt.attrs...)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("MetricValue(%q) = %v, %v; want %v, %v", tt.metric, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReset(t *testing.T) {
+	rec := observabilitytest.New(t)
+	ctx := context.Background()
+
+	_, span := observability.StartSpan(ctx, "before reset")
+	span.End()
+	observability.Logger(ctx).Info(ctx, "before reset")
+	testMetrics.Get().histogram.Record(ctx, 1)
+	if len(rec.Spans()) != 1 || len(rec.Logs()) != 1 {
+		t.Fatalf("before Reset: %d spans, %d logs; want 1 each", len(rec.Spans()), len(rec.Logs()))
+	}
+
+	rec.Reset()
+
+	if n := len(rec.Spans()); n != 0 {
+		t.Errorf("spans after Reset = %d, want 0", n)
+	}
+	if n := len(rec.Logs()); n != 0 {
+		t.Errorf("logs after Reset = %d, want 0", n)
+	}
+	if v, ok := rec.MetricValue("test.latency"); ok {
+		t.Errorf("test.latency after Reset = %v, want no data", v)
+	}
+
+	// Instrumentation keeps working against the new stack.
+	_, span = observability.StartSpan(ctx, "after reset")
+	span.End()
+	testMetrics.Get().histogram.Record(ctx, 1)
+	rec.AssertSpan("after reset")
+	if v, _ := rec.MetricValue("test.latency"); v != 1 {
+		t.Errorf("test.latency after Reset and Record = %v, want 1", v)
+	}
+}
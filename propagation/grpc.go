@@ -3,7 +3,6 @@ package propagation
 import (
 	"context"
 
-	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -35,10 +34,7 @@ func (c grpcMetadataCarrier) Keys() []string {
 // ExtractGrpc extracts trace context from gRPC incoming metadata into ctx.
 func ExtractGrpc(ctx context.Context, md metadata.MD) context.Context {
 	carrier := grpcMetadataCarrier{md: md}
-	return propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	).Extract(ctx, carrier)
+	return Propagator().Extract(ctx, carrier)
 }
 
 // InjectGrpc injects trace context from ctx into gRPC outgoing metadata.
@@ -48,9 +44,6 @@ func InjectGrpc(ctx context.Context, md metadata.MD) metadata.MD {
 		md = metadata.New(nil)
 	}
 	carrier := grpcMetadataCarrier{md: md}
-	propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	).Inject(ctx, carrier)
+	Propagator().Inject(ctx, carrier)
 	return md
 }
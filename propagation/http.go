@@ -5,8 +5,6 @@ package propagation
 import (
 	"context"
 	"net/http"
-
-	"go.opentelemetry.io/otel/propagation"
 )
 
 // HTTPHeaderCarrier adapts http.Header to the propagation.TextMapCarrier interface.
@@ -34,21 +32,15 @@ func (c HTTPHeaderCarrier) Keys() []string {
 }
 
 // ExtractHTTP extracts trace context from HTTP request headers into ctx.
-// Uses the global propagator. Call before starting a span for incoming requests.
+// Uses the configured propagator (see SetPropagator). Call before starting a span for incoming requests.
 func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
 	carrier := HTTPHeaderCarrier{Header: header}
-	return propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	).Extract(ctx, carrier)
+	return Propagator().Extract(ctx, carrier)
 }
 
 // InjectHTTP injects trace context from ctx into HTTP request headers.
 // Call before sending outgoing HTTP requests.
 func InjectHTTP(ctx context.Context, header http.Header) {
 	carrier := HTTPHeaderCarrier{Header: header}
-	propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	).Inject(ctx, carrier)
+	Propagator().Inject(ctx, carrier)
 }
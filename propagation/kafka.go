@@ -1,10 +1,6 @@
 package propagation
 
-import (
-	"context"
-
-	"go.opentelemetry.io/otel/propagation"
-)
+import "context"
 
 // KafkaHeaderCarrier adapts map[string]string (Kafka-style headers) to propagation.TextMapCarrier.
 // Kafka headers are typically represented as key-value strings for trace propagation.
@@ -41,10 +37,7 @@ func ExtractKafka(ctx context.Context, headers map[string]string) context.Contex
 		return ctx
 	}
 	carrier := KafkaHeaderCarrier{Headers: headers}
-	return propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	).Extract(ctx, carrier)
+	return Propagator().Extract(ctx, carrier)
 }
 
 // InjectKafka injects trace context from ctx into a new map suitable for Kafka headers.
@@ -52,9 +45,99 @@ func ExtractKafka(ctx context.Context, headers map[string]string) context.Contex
 func InjectKafka(ctx context.Context) map[string]string {
 	headers := make(map[string]string)
 	carrier := KafkaHeaderCarrier{Headers: headers}
-	propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	).Inject(ctx, carrier)
+	Propagator().Inject(ctx, carrier)
 	return headers
 }
+
+// KafkaHeader is one binary Kafka record header, the shape Kafka clients use.
+type KafkaHeader struct {
+	Key   string
+	Value []byte
+}
+
+// KafkaHeaderShape is satisfied by any header type whose underlying type is KafkaHeader's, such as
+// kgo.RecordHeader (franz-go) and kafka.Header (segmentio/kafka-go), so their slices can be used
+// without copying. See the otelsarama package for sarama's []byte keys.
+type KafkaHeaderShape interface {
+	~struct {
+		Key   string
+		Value []byte
+	}
+}
+
+// KafkaHeadersCarrier adapts a slice of binary Kafka headers to propagation.TextMapCarrier.
+// Unlike a map, the slice keeps duplicate keys: Get returns the last value for a key (the one
+// appended by the most recent hop) and Set replaces every header with the key by a single one, so
+// re-injecting into a forwarded record does not accumulate stale traceparent headers.
+type KafkaHeadersCarrier[H KafkaHeaderShape] struct {
+	Headers *[]H
+}
+
+// Get returns the last value for the given key.
+func (c KafkaHeadersCarrier[H]) Get(key string) string {
+	headers := *c.Headers
+	for i := len(headers) - 1; i >= 0; i-- {
+		if h := KafkaHeader(headers[i]); h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the headers with the given key by one header with value, in place of the first.
+func (c KafkaHeadersCarrier[H]) Set(key, value string) {
+	headers := *c.Headers
+	out := make([]H, 0, len(headers)+1)
+	replaced := false
+	for _, h := range headers {
+		if KafkaHeader(h).Key != key {
+			out = append(out, h)
+			continue
+		}
+		if !replaced {
+			out = append(out, H(KafkaHeader{Key: key, Value: []byte(value)}))
+			replaced = true
+		}
+	}
+	if !replaced {
+		out = append(out, H(KafkaHeader{Key: key, Value: []byte(value)}))
+	}
+	*c.Headers = out
+}
+
+// Keys returns the distinct keys in the carrier, in order of first occurrence.
+func (c KafkaHeadersCarrier[H]) Keys() []string {
+	keys := make([]string, 0, len(*c.Headers))
+	seen := make(map[string]bool, len(*c.Headers))
+	for _, h := range *c.Headers {
+		if k := KafkaHeader(h).Key; !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// ExtractKafkaRecordHeaders extracts trace context from binary Kafka record headers into ctx.
+func ExtractKafkaRecordHeaders[H KafkaHeaderShape](ctx context.Context, headers []H) context.Context {
+	return Propagator().Extract(ctx, KafkaHeadersCarrier[H]{Headers: &headers})
+}
+
+// InjectKafkaRecordHeaders injects trace context from ctx into binary Kafka record headers,
+// replacing existing trace headers, and returns the updated slice
+// (e.g., rec.Headers = InjectKafkaRecordHeaders(ctx, rec.Headers) for a kgo.Record or kafka.Message).
+func InjectKafkaRecordHeaders[H KafkaHeaderShape](ctx context.Context, headers []H) []H {
+	Propagator().Inject(ctx, KafkaHeadersCarrier[H]{Headers: &headers})
+	return headers
+}
+
+// KafkaRecordHeadersMap converts binary Kafka record headers to the map used by ExtractKafka and
+// tracing.KafkaConsumerMessage. The last value wins for duplicate keys.
+func KafkaRecordHeadersMap[H KafkaHeaderShape](headers []H) map[string]string {
+	m := make(map[string]string, len(headers))
+	for _, h := range headers {
+		kh := KafkaHeader(h)
+		m[kh.Key] = string(kh.Value)
+	}
+	return m
+}
@@ -0,0 +1,146 @@
+package propagation_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordHeader has the shape of kgo.RecordHeader and kafka.Header.
+type recordHeader struct {
+	Key   string
+	Value []byte
+}
+
+func h(key, value string) recordHeader {
+	return recordHeader{Key: key, Value: []byte(value)}
+}
+
+func headerStrings(headers []recordHeader) []string {
+	out := make([]string, len(headers))
+	for i, hd := range headers {
+		out[i] = hd.Key + "=" + string(hd.Value)
+	}
+	return out
+}
+
+func TestKafkaHeadersCarrierGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []recordHeader
+		key     string
+		want    string
+	}{
+		{name: "missing", headers: []recordHeader{h("a", "1")}, key: "b", want: ""},
+		{name: "empty", key: "a", want: ""},
+		{name: "single", headers: []recordHeader{h("a", "1"), h("b", "2")}, key: "b", want: "2"},
+		{name: "duplicate returns last", headers: []recordHeader{h("a", "1"), h("b", "2"), h("a", "3")}, key: "a", want: "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := propagation.KafkaHeadersCarrier[recordHeader]{Headers: &tt.headers}
+			if got := c.Get(tt.key); got != tt.want {
+				t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKafkaHeadersCarrierSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []recordHeader
+		want    []string
+	}{
+		{name: "empty", want: []string{"traceparent=new"}},
+		{name: "appends", headers: []recordHeader{h("a", "1")}, want: []string{"a=1", "traceparent=new"}},
+		{
+			name:    "replaces in place",
+			headers: []recordHeader{h("a", "1"), h("traceparent", "old"), h("b", "2")},
+			want:    []string{"a=1", "traceparent=new", "b=2"},
+		},
+		{
+			name:    "collapses duplicates",
+			headers: []recordHeader{h("traceparent", "old1"), h("a", "1"), h("traceparent", "old2")},
+			want:    []string{"traceparent=new", "a=1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := slices.Clone(tt.headers)
+			headers := tt.headers
+			c := propagation.KafkaHeadersCarrier[recordHeader]{Headers: &headers}
+			c.Set("traceparent", "new")
+			if got := headerStrings(headers); !slices.Equal(got, tt.want) {
+				t.Errorf("headers = %v, want %v", got, tt.want)
+			}
+			if !slices.EqualFunc(tt.headers, orig, func(a, b recordHeader) bool {
+				return a.Key == b.Key && string(a.Value) == string(b.Value)
+			}) {
+				t.Errorf("Set modified the original slice: %v", headerStrings(tt.headers))
+			}
+		})
+	}
+}
+
+func TestKafkaHeadersCarrierKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []recordHeader
+		want    []string
+	}{
+		{name: "empty", want: []string{}},
+		{name: "distinct in first-occurrence order", headers: []recordHeader{h("b", "1"), h("a", "2"), h("b", "3")}, want: []string{"b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := propagation.KafkaHeadersCarrier[recordHeader]{Headers: &tt.headers}
+			if got := c.Keys(); !slices.Equal(got, tt.want) {
+				t.Errorf("Keys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	oldSC = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	newSC = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{2},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+)
+
+func TestKafkaRecordHeadersRoundTrip(t *testing.T) {
+	var headers []recordHeader
+	headers = propagation.InjectKafkaRecordHeaders(trace.ContextWithSpanContext(context.Background(), oldSC), headers)
+	headers = append(headers, h("app", "x"))
+	// A forwarding consumer re-injects its own context into the same record.
+	headers = propagation.InjectKafkaRecordHeaders(trace.ContextWithSpanContext(context.Background(), newSC), headers)
+
+	n := 0
+	for _, hd := range headers {
+		if hd.Key == "traceparent" {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("traceparent headers = %d, want 1: %v", n, headerStrings(headers))
+	}
+
+	got := trace.SpanContextFromContext(propagation.ExtractKafkaRecordHeaders(context.Background(), headers))
+	if got.TraceID() != newSC.TraceID() {
+		t.Errorf("extracted trace ID = %s, want %s", got.TraceID(), newSC.TraceID())
+	}
+	if m := propagation.KafkaRecordHeadersMap(headers); m["app"] != "x" || m["traceparent"] == "" {
+		t.Errorf("KafkaRecordHeadersMap = %v", m)
+	}
+}
@@ -0,0 +1,71 @@
+// Package otelsarama propagates trace context through IBM/sarama record headers. It does not
+// import sarama: the functions accept any header type shaped like sarama.RecordHeader, so
+// services keep their own sarama version and this module stays free of Kafka client dependencies.
+//
+//	// Producer
+//	msg.Headers = otelsarama.Inject(ctx, msg.Headers)
+//
+//	// Consumer
+//	ctx = otelsarama.Extract(ctx, msg.Headers)
+package otelsarama
+
+import (
+	"context"
+
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
+)
+
+// Header is satisfied by sarama.RecordHeader, whose key is a []byte.
+type Header interface {
+	~struct {
+		Key   []byte
+		Value []byte
+	}
+}
+
+// binaryHeader is the underlying type of every Header, used to read and build them.
+type binaryHeader struct {
+	Key   []byte
+	Value []byte
+}
+
+// Inject injects trace context from ctx into a producer message's headers
+// (sarama.ProducerMessage.Headers), replacing existing trace headers, and returns the updated slice.
+func Inject[H Header](ctx context.Context, headers []H) []H {
+	kh := make([]propagation.KafkaHeader, len(headers))
+	for i, h := range headers {
+		b := binaryHeader(h)
+		kh[i] = propagation.KafkaHeader{Key: string(b.Key), Value: b.Value}
+	}
+	kh = propagation.InjectKafkaRecordHeaders(ctx, kh)
+
+	out := make([]H, len(kh))
+	for i, h := range kh {
+		out[i] = H(binaryHeader{Key: []byte(h.Key), Value: h.Value})
+	}
+	return out
+}
+
+// Extract extracts trace context from a consumer message's headers
+// (sarama.ConsumerMessage.Headers, a []*sarama.RecordHeader) into ctx.
+func Extract[H Header](ctx context.Context, headers []*H) context.Context {
+	return propagation.ExtractKafkaRecordHeaders(ctx, convert(headers))
+}
+
+// Map converts a consumer message's headers to the map used by tracing.KafkaConsumerMessage.
+// The last value wins for duplicate keys.
+func Map[H Header](headers []*H) map[string]string {
+	return propagation.KafkaRecordHeadersMap(convert(headers))
+}
+
+func convert[H Header](headers []*H) []propagation.KafkaHeader {
+	kh := make([]propagation.KafkaHeader, 0, len(headers))
+	for _, h := range headers {
+		if h == nil {
+			continue
+		}
+		b := binaryHeader(*h)
+		kh = append(kh, propagation.KafkaHeader{Key: string(b.Key), Value: b.Value})
+	}
+	return kh
+}
@@ -0,0 +1,121 @@
+package otelsarama_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MH-Cognition/mhc-infra-observability/propagation/otelsarama"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordHeader has the shape of sarama.RecordHeader.
+type RecordHeader struct {
+	Key   []byte
+	Value []byte
+}
+
+func spanContext(id byte) trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{id},
+		SpanID:     trace.SpanID{id},
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func traceparent(id byte) string {
+	sc := spanContext(id)
+	return "00-" + sc.TraceID().String() + "-" + sc.SpanID().String() + "-01"
+}
+
+func TestInject(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []RecordHeader
+		want    []string // keys in order
+	}{
+		{name: "empty", want: []string{"traceparent"}},
+		{
+			name:    "replaces existing traceparent",
+			headers: []RecordHeader{{Key: []byte("app"), Value: []byte("x")}, {Key: []byte("traceparent"), Value: []byte(traceparent(1))}},
+			want:    []string{"app", "traceparent"},
+		},
+		{
+			name: "collapses duplicated traceparent",
+			headers: []RecordHeader{
+				{Key: []byte("traceparent"), Value: []byte(traceparent(1))},
+				{Key: []byte("app"), Value: []byte("x")},
+				{Key: []byte("traceparent"), Value: []byte(traceparent(3))},
+			},
+			want: []string{"traceparent", "app"},
+		},
+	}
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext(2))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := otelsarama.Inject(ctx, tt.headers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d headers, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, hd := range got {
+				if string(hd.Key) != tt.want[i] {
+					t.Errorf("header %d key = %q, want %q", i, hd.Key, tt.want[i])
+				}
+				if string(hd.Key) == "traceparent" && string(hd.Value) != traceparent(2) {
+					t.Errorf("traceparent = %q, want %q", hd.Value, traceparent(2))
+				}
+			}
+		})
+	}
+}
+
+func TestExtractAndMap(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     []*RecordHeader
+		wantTraceID byte // 0 for no trace context
+		wantMap     map[string]string
+	}{
+		{name: "no headers", wantMap: map[string]string{}},
+		{name: "only nil entries", headers: []*RecordHeader{nil, nil}, wantMap: map[string]string{}},
+		{
+			name:        "nil entries skipped",
+			headers:     []*RecordHeader{nil, {Key: []byte("traceparent"), Value: []byte(traceparent(1))}, nil},
+			wantTraceID: 1,
+			wantMap:     map[string]string{"traceparent": traceparent(1)},
+		},
+		{
+			name: "duplicated key uses last",
+			headers: []*RecordHeader{
+				{Key: []byte("traceparent"), Value: []byte(traceparent(1))},
+				nil,
+				{Key: []byte("app"), Value: []byte("x")},
+				{Key: []byte("traceparent"), Value: []byte(traceparent(2))},
+			},
+			wantTraceID: 2,
+			wantMap:     map[string]string{"traceparent": traceparent(2), "app": "x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := trace.SpanContextFromContext(otelsarama.Extract(context.Background(), tt.headers))
+			if tt.wantTraceID == 0 {
+				if sc.IsValid() {
+					t.Errorf("extracted %s, want no trace context", sc.TraceID())
+				}
+			} else if sc.TraceID() != spanContext(tt.wantTraceID).TraceID() {
+				t.Errorf("extracted trace ID %s, want %s", sc.TraceID(), spanContext(tt.wantTraceID).TraceID())
+			}
+
+			m := otelsarama.Map(tt.headers)
+			if len(m) != len(tt.wantMap) {
+				t.Errorf("Map = %v, want %v", m, tt.wantMap)
+			}
+			for k, v := range tt.wantMap {
+				if m[k] != v {
+					t.Errorf("Map[%q] = %q, want %q", k, m[k], v)
+				}
+			}
+		})
+	}
+}
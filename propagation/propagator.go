@@ -0,0 +1,80 @@
+package propagation
+
+import (
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator names accepted in OTEL_PROPAGATORS.
+const (
+	NameTraceContext = "tracecontext"
+	NameBaggage      = "baggage"
+	NameB3           = "b3"      // B3 single header ("b3")
+	NameB3Multi      = "b3multi" // B3 multi header ("X-B3-TraceId", ...)
+	NameJaeger       = "jaeger"  // "uber-trace-id"
+	NameXRay         = "xray"    // AWS "X-Amzn-Trace-Id"
+	NameNone         = "none"
+)
+
+var (
+	mu         sync.RWMutex
+	propagator propagation.TextMapPropagator // set via SetPropagator; nil means the default
+)
+
+// Default returns the default propagator: W3C TraceContext + Baggage.
+func Default() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+}
+
+// FromNames builds a composite propagator from OTEL_PROPAGATORS names, in order. All listed formats
+// are extracted and injected, so services can accept B3 or Jaeger headers from upstream while still
+// emitting W3C TraceContext. Unknown names are skipped; "none" or an empty list disables propagation.
+func FromNames(names ...string) propagation.TextMapPropagator {
+	props := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case NameTraceContext:
+			props = append(props, propagation.TraceContext{})
+		case NameBaggage:
+			props = append(props, propagation.Baggage{})
+		case NameB3:
+			props = append(props, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case NameB3Multi:
+			props = append(props, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case NameJaeger:
+			props = append(props, jaeger.Jaeger{})
+		case NameXRay:
+			props = append(props, xray.Propagator{})
+		case NameNone:
+			return propagation.NewCompositeTextMapPropagator()
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(props...)
+}
+
+// SetPropagator sets the propagator used by every Extract/Inject helper in this package.
+// Called from tracing Init; pass nil to restore Default.
+func SetPropagator(p propagation.TextMapPropagator) {
+	mu.Lock()
+	defer mu.Unlock()
+	propagator = p
+}
+
+// Propagator returns the configured propagator, or Default if none was set.
+func Propagator() propagation.TextMapPropagator {
+	mu.RLock()
+	p := propagator
+	mu.RUnlock()
+	if p != nil {
+		return p
+	}
+	return Default()
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// newExporter creates the OTLP span exporter for the configured protocol, transport security,
+// headers and compression.
+func newExporter(ctx context.Context, o config.OTLP) (sdktrace.SpanExporter, error) {
+	switch o.Protocol {
+	case config.ProtocolGRPC:
+		var opts []otlptracegrpc.Option
+		if o.HasScheme() {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(o.Endpoint))
+		} else {
+			opts = append(opts, otlptracegrpc.WithEndpoint(o.Endpoint))
+		}
+		if len(o.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(o.Headers))
+		}
+		if o.Compression == config.CompressionGzip {
+			opts = append(opts, otlptracegrpc.WithCompressor(config.CompressionGzip))
+		}
+		if o.Secure() {
+			tlsCfg, err := o.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		} else {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case config.ProtocolHTTPProtobuf:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(o.Endpoint)}
+		if len(o.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(o.Headers))
+		}
+		if o.Compression == config.CompressionGzip {
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if o.Secure() {
+			tlsCfg, err := o.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", o.Protocol)
+	}
+}
@@ -2,6 +2,9 @@ package tracing
 
 import (
 	"context"
+	"net"
+	"strconv"
+	"strings"
 
 	"github.com/MH-Cognition/mhc-infra-observability/propagation"
 
@@ -9,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -16,36 +20,35 @@ import (
 
 // UnaryServerInterceptor returns a gRPC unary server interceptor that extracts
 // trace context from incoming metadata, starts a span, and injects context.
-func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+// Health checks (GrpcHealthMethodPrefix) and methods filtered via opts are not traced.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
 	return func(
 		ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
+		if !o.traceGrpc(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
 		md, _ := metadata.FromIncomingContext(ctx)
 		ctx = propagation.ExtractGrpc(ctx, md)
 
 		tracer := Tracer()
 		ctx, span := tracer.Start(ctx, info.FullMethod,
 			trace.WithSpanKind(trace.SpanKindServer),
-			trace.WithAttributes(
-				attribute.String("rpc.system", "grpc"),
-				attribute.String("rpc.service", info.FullMethod),
-			),
+			trace.WithAttributes(rpcAttributes(info.FullMethod)...),
 		)
 		defer span.End()
 
 		if p, ok := peer.FromContext(ctx); ok {
-			span.SetAttributes(attribute.String("peer.address", p.Addr.String()))
+			span.SetAttributes(peerAttributes(p.Addr, true)...)
 		}
 
 		resp, err := handler(ctx, req)
-		if err != nil {
-			st, _ := status.FromError(err)
-			span.SetStatus(codes.Error, st.Message())
-			span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
-		}
+		recordGrpcStatus(span, err, true)
 		return resp, err
 	}
 }
@@ -64,10 +67,7 @@ func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 		tracer := Tracer()
 		ctx, span := tracer.Start(ctx, method,
 			trace.WithSpanKind(trace.SpanKindClient),
-			trace.WithAttributes(
-				attribute.String("rpc.system", "grpc"),
-				attribute.String("rpc.service", method),
-			),
+			trace.WithAttributes(rpcAttributes(method)...),
 		)
 		defer span.End()
 
@@ -78,12 +78,76 @@ func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 		md = propagation.InjectGrpc(ctx, md)
 		ctx = metadata.NewOutgoingContext(ctx, md)
 
-		err := invoker(ctx, method, req, reply, cc, opts...)
-		if err != nil {
-			st, _ := status.FromError(err)
-			span.SetStatus(codes.Error, st.Message())
-			span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
-		}
+		var p peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+		span.SetAttributes(peerAttributes(p.Addr, false)...)
+		recordGrpcStatus(span, err, false)
 		return err
 	}
 }
+
+// rpcAttributes returns the rpc.* span attributes for a full method ("/pkg.Service/Method").
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := splitFullMethod(fullMethod)
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+	}
+}
+
+// splitFullMethod splits "/pkg.Service/Method" into "pkg.Service" and "Method".
+func splitFullMethod(fullMethod string) (service, method string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// peerAttributes returns the address of the peer: client.address and client.port on a server
+// span (the caller), server.address and server.port on a client span (the callee).
+func peerAttributes(addr net.Addr, server bool) []attribute.KeyValue {
+	if addr == nil {
+		return nil
+	}
+	addrKey, portKey := "server.address", "server.port"
+	if server {
+		addrKey, portKey = "client.address", "client.port"
+	}
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return []attribute.KeyValue{attribute.String(addrKey, addr.String())}
+	}
+	attrs := []attribute.KeyValue{attribute.String(addrKey, host)}
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, attribute.Int(portKey, p))
+	}
+	return attrs
+}
+
+// serverErrorCodes are the status codes that mark a server span as an error; the others
+// (NotFound, InvalidArgument, ...) are the caller's fault and leave the server span unset.
+var serverErrorCodes = map[grpccodes.Code]bool{
+	grpccodes.Unknown:          true,
+	grpccodes.DeadlineExceeded: true,
+	grpccodes.Unimplemented:    true,
+	grpccodes.Internal:         true,
+	grpccodes.Unavailable:      true,
+	grpccodes.DataLoss:         true,
+}
+
+// recordGrpcStatus sets rpc.grpc.status_code (OK included) and marks the span as an error for
+// any non-OK code on the client, and for serverErrorCodes on the server. Errors that carry no
+// gRPC status are treated as Unknown, context errors as Canceled / DeadlineExceeded.
+func recordGrpcStatus(span trace.Span, err error, server bool) grpccodes.Code {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
+	if st.Code() != grpccodes.OK && (!server || serverErrorCodes[st.Code()]) {
+		span.SetStatus(codes.Error, st.Message())
+	}
+	return st.Code()
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"github.com/MH-Cognition/mhc-infra-observability/metrics"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// rpcInstruments are the RPC metrics from the OTel RPC semantic conventions, recorded by the
+// gRPC stats handlers. Durations are in milliseconds and sizes are per message, uncompressed.
+type rpcInstruments struct {
+	serverDuration     metric.Float64Histogram
+	serverRequestSize  metric.Int64Histogram
+	serverResponseSize metric.Int64Histogram
+	clientDuration     metric.Float64Histogram
+	clientRequestSize  metric.Int64Histogram
+	clientResponseSize metric.Int64Histogram
+}
+
+var rpcMetrics = metrics.NewLazy(func(m metric.Meter) rpcInstruments {
+	serverDuration, _ := m.Float64Histogram("rpc.server.duration",
+		metric.WithDescription("Duration of inbound RPCs."),
+		metric.WithUnit("ms"),
+	)
+	serverRequestSize, _ := m.Int64Histogram("rpc.server.request.size",
+		metric.WithDescription("Size of inbound RPC request messages."),
+		metric.WithUnit("By"),
+	)
+	serverResponseSize, _ := m.Int64Histogram("rpc.server.response.size",
+		metric.WithDescription("Size of outbound RPC response messages."),
+		metric.WithUnit("By"),
+	)
+	clientDuration, _ := m.Float64Histogram("rpc.client.duration",
+		metric.WithDescription("Duration of outbound RPCs."),
+		metric.WithUnit("ms"),
+	)
+	clientRequestSize, _ := m.Int64Histogram("rpc.client.request.size",
+		metric.WithDescription("Size of outbound RPC request messages."),
+		metric.WithUnit("By"),
+	)
+	clientResponseSize, _ := m.Int64Histogram("rpc.client.response.size",
+		metric.WithDescription("Size of inbound RPC response messages."),
+		metric.WithUnit("By"),
+	)
+	return rpcInstruments{
+		serverDuration:     serverDuration,
+		serverRequestSize:  serverRequestSize,
+		serverResponseSize: serverResponseSize,
+		clientDuration:     clientDuration,
+		clientRequestSize:  clientRequestSize,
+		clientResponseSize: clientResponseSize,
+	}
+})
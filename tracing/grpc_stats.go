@@ -0,0 +1,130 @@
+package tracing
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+)
+
+// StatsHandler is a gRPC stats.Handler that traces every RPC (unary and streaming) and records
+// the RPC metrics: rpc.server.duration / rpc.client.duration and the per-message request and
+// response size histograms. Unlike the interceptors it sees time spent in the transport and
+// the actual payload sizes. Install it with grpc.StatsHandler (server) or
+// grpc.WithStatsHandler (client), instead of the interceptors rather than alongside them.
+type StatsHandler struct {
+	client bool
+	o      *options
+}
+
+// NewServerStatsHandler returns a StatsHandler for a gRPC server. It extracts trace context
+// from incoming metadata; the handler's ctx carries the server span. Health checks
+// (GrpcHealthMethodPrefix) and methods filtered via opts are not traced or measured.
+func NewServerStatsHandler(opts ...Option) *StatsHandler {
+	return &StatsHandler{o: newOptions(opts)}
+}
+
+// NewClientStatsHandler returns a StatsHandler for a gRPC client. It starts a client span per
+// RPC and injects trace context into outgoing metadata.
+func NewClientStatsHandler() *StatsHandler {
+	return &StatsHandler{client: true, o: &options{}}
+}
+
+// rpcStateKey holds the *rpcState of the RPC in its context.
+type rpcStateKey struct{}
+
+type rpcState struct {
+	span        trace.Span
+	attrs       []attribute.KeyValue
+	sent, recvd atomic.Int64
+}
+
+// TagRPC starts the RPC span and attaches it to the context used for the rest of the RPC.
+func (h *StatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	kind := trace.SpanKindClient
+	if !h.client {
+		if !h.o.traceGrpc(info.FullMethodName) {
+			return ctx
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = propagation.ExtractGrpc(ctx, md)
+		kind = trace.SpanKindServer
+	}
+
+	attrs := rpcAttributes(info.FullMethodName)
+	ctx, span := Tracer().Start(ctx, info.FullMethodName,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(attrs...),
+	)
+	if p, ok := peer.FromContext(ctx); ok {
+		span.SetAttributes(peerAttributes(p.Addr, !h.client)...)
+	}
+
+	if h.client {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if !ok {
+			md = metadata.New(nil)
+		}
+		md = propagation.InjectGrpc(ctx, md)
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	return context.WithValue(ctx, rpcStateKey{}, &rpcState{span: span, attrs: attrs})
+}
+
+// HandleRPC records message events and sizes, and ends the span with the RPC status.
+func (h *StatsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	st, ok := ctx.Value(rpcStateKey{}).(*rpcState)
+	if !ok {
+		return
+	}
+	ins := rpcMetrics.Get()
+	set := metric.WithAttributeSet(attribute.NewSet(st.attrs...))
+
+	switch rs := rs.(type) {
+	case *stats.InPayload:
+		messageSizeEvent(st.span, "RECEIVED", st.recvd.Add(1), rs.Length)
+		if h.client {
+			ins.clientResponseSize.Record(ctx, int64(rs.Length), set)
+		} else {
+			ins.serverRequestSize.Record(ctx, int64(rs.Length), set)
+		}
+	case *stats.OutPayload:
+		messageSizeEvent(st.span, "SENT", st.sent.Add(1), rs.Length)
+		if h.client {
+			ins.clientRequestSize.Record(ctx, int64(rs.Length), set)
+		} else {
+			ins.serverResponseSize.Record(ctx, int64(rs.Length), set)
+		}
+	case *stats.OutHeader:
+		if h.client {
+			st.span.SetAttributes(peerAttributes(rs.RemoteAddr, false)...)
+		}
+	case *stats.End:
+		code := recordGrpcStatus(st.span, rs.Error, !h.client)
+		st.span.End()
+
+		attrs := append(st.attrs[:len(st.attrs):len(st.attrs)], attribute.Int("rpc.grpc.status_code", int(code)))
+		elapsed := float64(rs.EndTime.Sub(rs.BeginTime)) / 1e6
+		endSet := metric.WithAttributeSet(attribute.NewSet(attrs...))
+		if h.client {
+			ins.clientDuration.Record(ctx, elapsed, endSet)
+		} else {
+			ins.serverDuration.Record(ctx, elapsed, endSet)
+		}
+	}
+}
+
+// TagConn implements stats.Handler; connections are not traced.
+func (h *StatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements stats.Handler; connections are not traced.
+func (h *StatsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,206 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// StreamServerInterceptor returns a gRPC stream server interceptor that extracts trace context
+// from incoming metadata and starts one span per stream. The handler sees the span through
+// ServerStream.Context(), and every message sent or received is recorded as an event.
+// Health checks (GrpcHealthMethodPrefix) and methods filtered via opts are not traced.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !o.traceGrpc(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx := ss.Context()
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = propagation.ExtractGrpc(ctx, md)
+
+		ctx, span := Tracer().Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(rpcAttributes(info.FullMethod)...),
+		)
+		defer span.End()
+
+		if p, ok := peer.FromContext(ctx); ok {
+			span.SetAttributes(peerAttributes(p.Addr, true)...)
+		}
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx, span: span})
+		recordGrpcStatus(span, err, true)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a gRPC stream client interceptor that injects trace context
+// into outgoing metadata and starts one span per stream, recording every message sent or
+// received as an event. The span ends when the stream finishes: RecvMsg returns an error
+// (io.EOF included), the single response of a client-streaming call arrives, or ctx is done.
+// The server address is recorded only when RecvMsg finishes the stream; a stream that ends on
+// cancellation or a failed send has no peer attributes.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, span := Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(rpcAttributes(method)...),
+		)
+
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if !ok {
+			md = metadata.New(nil)
+		}
+		md = propagation.InjectGrpc(ctx, md)
+		ctx = metadata.NewOutgoingContext(ctx, md)
+
+		s := &clientStream{
+			span:          span,
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			recordGrpcStatus(span, err, false)
+			span.End()
+			return nil, err
+		}
+
+		s.ClientStream = cs
+		go func() {
+			select {
+			case <-s.done:
+			case <-ctx.Done():
+				s.finish(ctx.Err(), false)
+			}
+		}()
+		return s, nil
+	}
+}
+
+// serverStream exposes the span context to the handler and records message events.
+type serverStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	span        trace.Span
+	sent, recvd atomic.Int64
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		messageEvent(s.span, "SENT", s.sent.Add(1), m)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		messageEvent(s.span, "RECEIVED", s.recvd.Add(1), m)
+	}
+	return err
+}
+
+// clientStream records message events and ends the span once the stream is finished.
+type clientStream struct {
+	grpc.ClientStream
+	span          trace.Span
+	serverStreams bool
+	sent, recvd   atomic.Int64
+	once          sync.Once
+	done          chan struct{}
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		messageEvent(s.span, "SENT", s.sent.Add(1), m)
+	} else if !errors.Is(err, io.EOF) {
+		// io.EOF means the stream was aborted; the status is returned by RecvMsg.
+		s.finish(err, false)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		messageEvent(s.span, "RECEIVED", s.recvd.Add(1), m)
+		if !s.serverStreams {
+			s.finish(nil, true)
+		}
+	case errors.Is(err, io.EOF):
+		s.finish(nil, true)
+	default:
+		s.finish(err, true)
+	}
+	return err
+}
+
+// finish ends the span once. withPeer must only be set after RecvMsg has returned, when
+// ClientStream.Context may be read; the cancellation goroutine must not touch the stream.
+func (s *clientStream) finish(err error, withPeer bool) {
+	s.once.Do(func() {
+		if withPeer {
+			if p, ok := peer.FromContext(s.ClientStream.Context()); ok {
+				s.span.SetAttributes(peerAttributes(p.Addr, false)...)
+			}
+		}
+		recordGrpcStatus(s.span, err, false)
+		s.span.End()
+		close(s.done)
+	})
+}
+
+// messageEvent records an rpc.message event; the size is set for protobuf messages.
+func messageEvent(span trace.Span, typ string, id int64, m interface{}) {
+	size := -1
+	if pm, ok := m.(proto.Message); ok {
+		size = proto.Size(pm)
+	}
+	messageSizeEvent(span, typ, id, size)
+}
+
+// messageSizeEvent records an rpc.message event for a message of size bytes (negative if unknown).
+func messageSizeEvent(span trace.Span, typ string, id int64, size int) {
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.message.type", typ),
+		attribute.Int64("rpc.message.id", id),
+	}
+	if size >= 0 {
+		attrs = append(attrs, attribute.Int("rpc.message.uncompressed_size", size))
+	}
+	span.AddEvent("rpc.message", trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,120 @@
+package tracing_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/MH-Cognition/mhc-infra-observability/observabilitytest"
+	"github.com/MH-Cognition/mhc-infra-observability/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const watchMethod = "/grpc.health.v1.Health/Watch"
+
+// newHealthClient serves the gRPC health service over an in-memory listener and returns a client
+// whose streams go through StreamClientInterceptor.
+func newHealthClient(t *testing.T) healthpb.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(tracing.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+// waitSpan waits for the span named name to end; a cancelled stream ends its span asynchronously.
+func waitSpan(t *testing.T, rec *observabilitytest.Recorder, name string) tracetest.SpanStub {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if s, ok := rec.FindSpan(name); ok {
+			return s
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("span %q did not end", name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func hasKey(attrs []attribute.KeyValue, key attribute.Key) bool {
+	for _, a := range attrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// TestStreamClientInterceptorCancel ends streams by cancelling their context, racing the
+// interceptor's cancellation goroutine against RecvMsg. Run with -race.
+func TestStreamClientInterceptorCancel(t *testing.T) {
+	tests := []struct {
+		name string
+		recv bool // keep calling Recv after cancelling
+	}{
+		{name: "cancel while receiving", recv: true},
+		{name: "cancel without receiving", recv: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := observabilitytest.New(t)
+			client := newHealthClient(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+			if err != nil {
+				t.Fatalf("Watch: %v", err)
+			}
+			if _, err := stream.Recv(); err != nil {
+				t.Fatalf("first Recv: %v", err)
+			}
+
+			cancel()
+			if tt.recv {
+				for {
+					if _, err := stream.Recv(); err != nil {
+						break
+					}
+				}
+			}
+
+			span := waitSpan(t, rec, watchMethod)
+			if !hasKey(span.Attributes, "rpc.grpc.status_code") {
+				t.Fatalf("span has no rpc.grpc.status_code: %v", span.Attributes)
+			}
+			for _, a := range span.Attributes {
+				if a.Key == "rpc.grpc.status_code" && a.Value.AsInt64() != int64(codes.Canceled) {
+					t.Errorf("rpc.grpc.status_code = %d, want %d", a.Value.AsInt64(), codes.Canceled)
+				}
+			}
+			if !tt.recv && hasKey(span.Attributes, "server.address") {
+				t.Errorf("span ended by cancellation has server.address: %v", span.Attributes)
+			}
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/MH-Cognition/mhc-infra-observability/propagation"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -17,17 +20,43 @@ const spanNamePrefix = "http."
 
 // Middleware returns an http.Handler that extracts trace context from request headers,
 // starts a root span per request, injects context into the request, and records span status.
+// It also records the HTTP server metrics (request duration, active requests, request and
+// response body sizes) independently of trace sampling.
 func Middleware(next http.Handler) http.Handler {
+	return NewMiddleware()(next)
+}
+
+// NewMiddleware returns middleware like Middleware, configured with opts
+// (e.g., WithIgnoredPaths to skip health checks and metrics scrapes). Filtered requests get
+// neither a span nor metrics.
+func NewMiddleware(opts ...Option) func(http.Handler) http.Handler {
+	o := newOptions(opts)
+	return func(next http.Handler) http.Handler {
+		return middleware(next, o)
+	}
+}
+
+func middleware(next http.Handler, o *options) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !o.traceHTTP(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		ins := httpServerMetrics.Get()
+		method, scheme := methodAttr(r.Method), schemeAttr(r)
+		activeAttrs := metric.WithAttributeSet(attribute.NewSet(method, scheme))
+		ins.active.Add(r.Context(), 1, activeAttrs)
+		defer ins.active.Add(r.Context(), -1, activeAttrs)
+
 		ctx := propagation.ExtractHTTP(r.Context(), r.Header)
 
+		// The route is only known once the mux has matched; the span is renamed after next returns.
 		tracer := Tracer()
-		spanName := spanNamePrefix + r.Method + " " + r.URL.Path
-		if spanName == spanNamePrefix+" " {
-			spanName = spanNamePrefix + r.URL.Path
-		}
-		if spanName == spanNamePrefix {
-			spanName = spanNamePrefix + "/"
+		spanName := spanNamePrefix + "HTTP"
+		if knownMethods[r.Method] {
+			spanName = spanNamePrefix + r.Method
 		}
 
 		ctx, span := tracer.Start(ctx, spanName,
@@ -44,28 +73,141 @@ func Middleware(next http.Handler) http.Handler {
 		defer span.End()
 
 		r = r.WithContext(ctx)
+		var body *countingBody
+		if r.Body != nil && r.Body != http.NoBody {
+			body = &countingBody{ReadCloser: r.Body}
+			r.Body = body
+		}
 
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 		next.ServeHTTP(wrapped, r)
 
+		route := o.route(r, wrapped.statusCode)
+		if route != "" {
+			spanName += " " + route
+			span.SetName(spanName)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+
 		span.SetAttributes(attribute.Int("http.status_code", wrapped.statusCode))
 		if wrapped.statusCode >= 400 {
 			span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(wrapped.statusCode))
 		}
+
+		attrs := []attribute.KeyValue{
+			method, scheme, protocolVersionAttr(r),
+			attribute.Int("http.response.status_code", wrapped.statusCode),
+		}
+		if route != "" {
+			attrs = append(attrs, attribute.String("http.route", route))
+		}
+		if wrapped.statusCode >= 500 {
+			attrs = append(attrs, attribute.String("error.type", strconv.Itoa(wrapped.statusCode)))
+		}
+		set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+		ins.duration.Record(ctx, time.Since(start).Seconds(), set)
+		var reqSize int64
+		if body != nil {
+			reqSize = body.n.Load()
+		}
+		ins.requestSize.Record(ctx, reqSize, set)
+		ins.responseSize.Record(ctx, wrapped.bytes, set)
 	})
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code.
+// route returns the low-cardinality route template for a served request: the WithRouteResolver
+// result, else the matched ServeMux pattern, else the path with ID-like segments collapsed.
+// Unmatched 404s return "" so scanners probing random paths cannot inflate cardinality.
+func (o *options) route(r *http.Request, status int) string {
+	if o.routeResolver != nil {
+		if route := o.routeResolver(r); route != "" {
+			return route
+		}
+	}
+	if route := routeFromPattern(r.Pattern); route != "" {
+		return route
+	}
+	if status == http.StatusNotFound {
+		return ""
+	}
+	return collapsePath(r.URL.Path)
+}
+
+// routeFromPattern returns the path part of a Go 1.22+ ServeMux pattern
+// ("GET example.com/orders/{id}" -> "/orders/{id}"), or "" when no pattern matched.
+func routeFromPattern(pattern string) string {
+	if pattern == "" {
+		return ""
+	}
+	if _, rest, ok := strings.Cut(pattern, " "); ok {
+		pattern = strings.TrimSpace(rest)
+	}
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
+
+// collapsePath replaces ID-like path segments (numbers, UUIDs, long tokens containing digits)
+// with "{id}": "/orders/123/items/9f8e..." -> "/orders/{id}/items/{id}".
+func collapsePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if isIDSegment(seg) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isIDSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	digits := 0
+	for _, c := range seg {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return digits == len(seg) || (digits > 0 && len(seg) >= 16)
+}
+
+// responseWriter wraps http.ResponseWriter to capture status code and response body size.
 type responseWriter struct {
 	http.ResponseWriter
-	statusCode int
+	statusCode  int
+	bytes       int64
+	wroteHeader bool
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
-	rw.statusCode = code
+	if !rw.wroteHeader {
+		rw.statusCode = code
+		rw.wroteHeader = true
+	}
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (Flush, Hijack, deadlines).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // InjectIntoRequest injects trace context into outgoing HTTP request headers.
 // Call before sending the request.
 func InjectIntoRequest(ctx context.Context, req *http.Request) {
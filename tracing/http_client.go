@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport is an http.RoundTripper that starts a client span for every request it sends,
+// injects trace context into the outgoing headers, and records the HTTP client metrics.
+//
+// Each attempt gets its own span. A redirect followed by http.Client is a child of the span
+// of the response that caused it, and carries http.request.resend_count; retries issued by the
+// caller (or by a retrying RoundTripper wrapping Transport) are children of the span in the
+// request context, so every attempt of one logical call shows up under one parent.
+// The span ends when the response headers arrive; reading the body is not included.
+type Transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport wraps base (http.DefaultTransport when nil) with tracing.
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base}
+}
+
+// resendKey carries the resend count of an attempt to the redirect that follows it.
+type resendKey struct{}
+
+// RoundTrip implements http.RoundTripper. The request is cloned before headers are injected,
+// so req is never modified.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	ctx := req.Context()
+
+	// http.Client sets req.Response on redirects; the previous attempt's request carries its span.
+	resend := 0
+	if req.Response != nil && req.Response.Request != nil {
+		prev := req.Response.Request.Context()
+		ctx = trace.ContextWithSpan(ctx, trace.SpanFromContext(prev))
+		n, _ := prev.Value(resendKey{}).(int)
+		resend = n + 1
+	}
+
+	method := methodAttr(req.Method)
+	host, port := serverAddress(req)
+	attrs := []attribute.KeyValue{
+		method,
+		attribute.String("url.full", req.URL.Redacted()),
+		attribute.String("server.address", host),
+	}
+	if port > 0 {
+		attrs = append(attrs, attribute.Int("server.port", port))
+	}
+	if resend > 0 {
+		attrs = append(attrs, attribute.Int("http.request.resend_count", resend))
+	}
+
+	spanName := spanNamePrefix + "HTTP"
+	if knownMethods[req.Method] {
+		spanName = spanNamePrefix + req.Method
+	}
+	ctx, span := Tracer().Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	ctx = context.WithValue(ctx, resendKey{}, resend)
+	out := req.Clone(ctx)
+	propagation.InjectHTTP(ctx, out.Header)
+
+	resp, err := t.base.RoundTrip(out)
+
+	metricAttrs := []attribute.KeyValue{method, attribute.String("server.address", host)}
+	if port > 0 {
+		metricAttrs = append(metricAttrs, attribute.Int("server.port", port))
+	}
+	if req.URL.Scheme != "" {
+		metricAttrs = append(metricAttrs, attribute.String("url.scheme", req.URL.Scheme))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		errType := clientErrorType(err)
+		span.SetAttributes(attribute.String("error.type", errType))
+		metricAttrs = append(metricAttrs, attribute.String("error.type", errType))
+	} else {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		metricAttrs = append(metricAttrs, attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(resp.StatusCode))
+			span.SetAttributes(attribute.String("error.type", strconv.Itoa(resp.StatusCode)))
+			metricAttrs = append(metricAttrs, attribute.String("error.type", strconv.Itoa(resp.StatusCode)))
+		}
+	}
+	httpClientMetrics.Get().duration.Record(ctx, time.Since(start).Seconds(),
+		metric.WithAttributeSet(attribute.NewSet(metricAttrs...)))
+
+	return resp, err
+}
+
+// serverAddress returns the host and port the request is sent to; the port defaults from the scheme.
+func serverAddress(req *http.Request) (string, int) {
+	host, portStr := req.URL.Hostname(), req.URL.Port()
+	if port, err := strconv.Atoi(portStr); err == nil {
+		return host, port
+	}
+	switch req.URL.Scheme {
+	case "https":
+		return host, 443
+	case "http":
+		return host, 80
+	}
+	return host, 0
+}
+
+// clientErrorType returns a low-cardinality error.type for a failed round trip.
+func clientErrorType(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	}
+	return fmt.Sprintf("%T", err)
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/MH-Cognition/mhc-infra-observability/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// durationBuckets are the OTel semantic-convention bucket boundaries (seconds) for
+// HTTP and RPC duration histograms.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+// httpServerInstruments are the HTTP server metrics from the OTel HTTP semantic conventions.
+type httpServerInstruments struct {
+	duration     metric.Float64Histogram
+	active       metric.Int64UpDownCounter
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+}
+
+// Instrument creation only fails for invalid names or units, which are constants here;
+// the SDK still returns a usable (noop) instrument alongside the error.
+var httpServerMetrics = metrics.NewLazy(func(m metric.Meter) httpServerInstruments {
+	duration, _ := m.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	active, _ := m.Int64UpDownCounter("http.server.active_requests",
+		metric.WithDescription("Number of active HTTP server requests."),
+		metric.WithUnit("{request}"),
+	)
+	requestSize, _ := m.Int64Histogram("http.server.request.body.size",
+		metric.WithDescription("Size of HTTP server request bodies."),
+		metric.WithUnit("By"),
+	)
+	responseSize, _ := m.Int64Histogram("http.server.response.body.size",
+		metric.WithDescription("Size of HTTP server response bodies."),
+		metric.WithUnit("By"),
+	)
+	return httpServerInstruments{
+		duration:     duration,
+		active:       active,
+		requestSize:  requestSize,
+		responseSize: responseSize,
+	}
+})
+
+// httpClientInstruments are the HTTP client metrics recorded by Transport.
+type httpClientInstruments struct {
+	duration metric.Float64Histogram
+}
+
+var httpClientMetrics = metrics.NewLazy(func(m metric.Meter) httpClientInstruments {
+	duration, _ := m.Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of HTTP client requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	return httpClientInstruments{duration: duration}
+})
+
+// knownMethods are the HTTP methods kept as-is in metric attributes; anything else is
+// reported as "_OTHER" to bound cardinality.
+var knownMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodConnect: true,
+	http.MethodOptions: true, http.MethodTrace: true,
+}
+
+// methodAttr returns http.request.method, normalized per semantic conventions.
+func methodAttr(method string) attribute.KeyValue {
+	if !knownMethods[method] {
+		method = "_OTHER"
+	}
+	return attribute.String("http.request.method", method)
+}
+
+// schemeAttr returns url.scheme for a server request (URL.Scheme is empty on the server side).
+func schemeAttr(r *http.Request) attribute.KeyValue {
+	if r.TLS != nil {
+		return attribute.String("url.scheme", "https")
+	}
+	return attribute.String("url.scheme", "http")
+}
+
+// protocolVersionAttr returns network.protocol.version (e.g., "1.1", "2").
+func protocolVersionAttr(r *http.Request) attribute.KeyValue {
+	v := strings.TrimPrefix(r.Proto, "HTTP/")
+	if v == "2.0" {
+		v = "2"
+	}
+	return attribute.String("network.protocol.version", v)
+}
+
+// countingBody counts bytes read from a request body.
+type countingBody struct {
+	io.ReadCloser
+	n atomic.Int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
@@ -2,13 +2,60 @@ package tracing
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/MH-Cognition/mhc-infra-observability/propagation"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Messaging operation types (messaging.operation.type).
+const (
+	kafkaOperationSend    = "send"
+	kafkaOperationProcess = "process"
+)
+
+// KafkaProducerMessage describes a record about to be produced, for StartKafkaProducerMessageSpan.
+type KafkaProducerMessage struct {
+	// Topic is the destination topic.
+	Topic string
+
+	// Key is the record key; nil for records without a key.
+	Key []byte
+
+	// BodySize is the size of the record value in bytes.
+	BodySize int
+
+	// ClientID is the producer's client.id (optional).
+	ClientID string
+}
+
+// KafkaConsumerMessage describes a consumed record, for StartKafkaConsumerMessageSpan.
+type KafkaConsumerMessage struct {
+	// Topic, Partition and Offset locate the record.
+	Topic     string
+	Partition int32
+	Offset    int64
+
+	// ConsumerGroup is the consumer group the record was read by (optional).
+	ConsumerGroup string
+
+	// Key is the record key; nil for records without a key.
+	Key []byte
+
+	// BodySize is the size of the record value in bytes.
+	BodySize int
+
+	// ClientID is the consumer's client.id (optional).
+	ClientID string
+
+	// Headers are the record headers. When set, the producer's trace context is extracted from
+	// them, so ExtractKafkaContext need not be called first.
+	Headers map[string]string
+}
+
 // InjectKafkaHeaders injects trace context into a map suitable for Kafka message headers.
 // Returns map[string]string that callers merge into their Kafka producer record.
 func InjectKafkaHeaders(ctx context.Context) map[string]string {
@@ -23,26 +70,183 @@ func ExtractKafkaContext(ctx context.Context, headers map[string]string) context
 
 // StartKafkaConsumerSpan starts a span for a Kafka message consumer.
 // Call after ExtractKafkaContext to create a child span for processing.
+// StartKafkaConsumerMessageSpan records more of the message.
 func StartKafkaConsumerSpan(ctx context.Context, topic, partition string, offset int64) (context.Context, trace.Span) {
-	return Tracer().Start(ctx, "kafka.consume",
+	return startKafkaSpan(ctx, "kafka.consume", kafkaOperationProcess, 1,
+		kafkaMetricAttrs(topic, partition, ""),
 		trace.WithSpanKind(trace.SpanKindConsumer),
 		trace.WithAttributes(
-			attribute.String("messaging.system", "kafka"),
-			attribute.String("messaging.destination", topic),
-			attribute.String("messaging.kafka.destination.partition", partition),
-			attribute.Int64("messaging.kafka.message.offset", offset),
+			kafkaSystem,
+			attribute.String("messaging.operation.type", kafkaOperationProcess),
+			attribute.String("messaging.destination.name", topic),
+			attribute.String("messaging.destination.partition.id", partition),
+			attribute.Int64("messaging.kafka.offset", offset),
 		),
 	)
 }
 
 // StartKafkaProducerSpan starts a span for producing a Kafka message.
 // Use the returned context when calling InjectKafkaHeaders.
+// StartKafkaProducerMessageSpan records more of the message.
 func StartKafkaProducerSpan(ctx context.Context, topic string) (context.Context, trace.Span) {
-	return Tracer().Start(ctx, "kafka.produce",
+	return startKafkaSpan(ctx, "kafka.produce", kafkaOperationSend, 0,
+		kafkaMetricAttrs(topic, "", ""),
 		trace.WithSpanKind(trace.SpanKindProducer),
 		trace.WithAttributes(
-			attribute.String("messaging.system", "kafka"),
-			attribute.String("messaging.destination", topic),
+			kafkaSystem,
+			attribute.String("messaging.operation.type", kafkaOperationSend),
+			attribute.String("messaging.destination.name", topic),
 		),
 	)
 }
+
+// StartKafkaProducerMessageSpan starts a producer span for msg with the messaging semantic
+// conventions (destination, operation type, key, body size). Inject the returned context into
+// the record with InjectKafkaHeaders, then call RecordKafkaProduceResult once the broker
+// acknowledges the record and end the span.
+func StartKafkaProducerMessageSpan(ctx context.Context, msg KafkaProducerMessage) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		kafkaSystem,
+		attribute.String("messaging.operation.type", kafkaOperationSend),
+		attribute.String("messaging.destination.name", msg.Topic),
+		attribute.Int("messaging.message.body.size", msg.BodySize),
+	}
+	attrs = appendKafkaCommon(attrs, msg.Key, msg.ClientID)
+	return startKafkaSpan(ctx, "kafka.produce", kafkaOperationSend, 0,
+		kafkaMetricAttrs(msg.Topic, "", ""),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// RecordKafkaProduceResult records the broker acknowledgement of a produced record on span: the
+// partition and offset it was written to, or err (which also marks the span as failed).
+func RecordKafkaProduceResult(span trace.Span, partition int32, offset int64, err error) {
+	ks, _ := span.(*kafkaSpan)
+	if err != nil {
+		errType := clientErrorType(err)
+		if ks != nil {
+			ks.fail(errType)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attribute.String("error.type", errType))
+		return
+	}
+	if ks != nil {
+		ks.setPartition(partition)
+	}
+	span.SetAttributes(
+		attribute.String("messaging.destination.partition.id", strconv.Itoa(int(partition))),
+		attribute.Int64("messaging.kafka.offset", offset),
+	)
+}
+
+// StartKafkaConsumerMessageSpan starts a consumer span for processing msg, continuing the trace
+// from msg.Headers when set, with the messaging semantic conventions (destination, partition,
+// offset, consumer group, key, body size). End the span when processing is done.
+func StartKafkaConsumerMessageSpan(ctx context.Context, msg KafkaConsumerMessage) (context.Context, trace.Span) {
+	if msg.Headers != nil {
+		ctx = propagation.ExtractKafka(ctx, msg.Headers)
+	}
+	return startKafkaSpan(ctx, "kafka.consume", kafkaOperationProcess, 1,
+		kafkaMetricAttrs(msg.Topic, strconv.Itoa(int(msg.Partition)), msg.ConsumerGroup),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(kafkaConsumerAttributes(msg)...),
+	)
+}
+
+var kafkaSystem = attribute.String("messaging.system", "kafka")
+
+// kafkaConsumerAttributes returns the span attributes for processing msg.
+func kafkaConsumerAttributes(msg KafkaConsumerMessage) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		kafkaSystem,
+		attribute.String("messaging.operation.type", kafkaOperationProcess),
+		attribute.String("messaging.destination.name", msg.Topic),
+		attribute.String("messaging.destination.partition.id", strconv.Itoa(int(msg.Partition))),
+		attribute.Int64("messaging.kafka.offset", msg.Offset),
+		attribute.Int("messaging.message.body.size", msg.BodySize),
+	}
+	if msg.ConsumerGroup != "" {
+		attrs = append(attrs, attribute.String("messaging.consumer.group.name", msg.ConsumerGroup))
+	}
+	return appendKafkaCommon(attrs, msg.Key, msg.ClientID)
+}
+
+func appendKafkaCommon(attrs []attribute.KeyValue, key []byte, clientID string) []attribute.KeyValue {
+	if key != nil {
+		attrs = append(attrs, attribute.String("messaging.kafka.message.key", string(key)))
+	}
+	if clientID != "" {
+		attrs = append(attrs, attribute.String("messaging.client.id", clientID))
+	}
+	return attrs
+}
+
+// StartKafkaBatchSpan starts a span for processing a batch of messages polled from topic. A batch
+// has no single parent, so the span is a child of ctx and links to the producer context extracted
+// from each message's Headers instead. For a span per message, call StartKafkaBatchMessageSpan
+// with the returned context.
+func StartKafkaBatchSpan(ctx context.Context, topic string, messages []KafkaConsumerMessage) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		kafkaSystem,
+		attribute.String("messaging.operation.type", kafkaOperationProcess),
+		attribute.String("messaging.destination.name", topic),
+		attribute.Int("messaging.batch.message_count", len(messages)),
+	}
+	if len(messages) > 0 && messages[0].ConsumerGroup != "" {
+		attrs = append(attrs, attribute.String("messaging.consumer.group.name", messages[0].ConsumerGroup))
+	}
+
+	links := make([]trace.Link, 0, len(messages))
+	for _, msg := range messages {
+		if link, ok := kafkaLink(msg); ok {
+			links = append(links, link)
+		}
+	}
+
+	group := ""
+	if len(messages) > 0 {
+		group = messages[0].ConsumerGroup
+	}
+	return startKafkaSpan(ctx, "kafka.process", kafkaOperationProcess, len(messages),
+		kafkaMetricAttrs(topic, "", group),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(links...),
+	)
+}
+
+// StartKafkaBatchMessageSpan starts a span for processing one message of a batch: a child of the
+// batch span in ctx (see StartKafkaBatchSpan), linked to the message's producer context. It
+// records no metrics; the batch span records the processing of the whole batch once.
+func StartKafkaBatchMessageSpan(ctx context.Context, msg KafkaConsumerMessage) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(kafkaConsumerAttributes(msg)...),
+	}
+	if link, ok := kafkaLink(msg); ok {
+		opts = append(opts, trace.WithLinks(link))
+	}
+	return Tracer().Start(ctx, "kafka.consume", opts...)
+}
+
+// kafkaLink returns a link to the producer span context in msg.Headers, if any, annotated with
+// the message's partition and offset.
+func kafkaLink(msg KafkaConsumerMessage) (trace.Link, bool) {
+	if msg.Headers == nil {
+		return trace.Link{}, false
+	}
+	sc := trace.SpanContextFromContext(propagation.ExtractKafka(context.Background(), msg.Headers))
+	if !sc.IsValid() {
+		return trace.Link{}, false
+	}
+	return trace.Link{
+		SpanContext: sc,
+		Attributes: []attribute.KeyValue{
+			attribute.String("messaging.destination.partition.id", strconv.Itoa(int(msg.Partition))),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+		},
+	}, true
+}
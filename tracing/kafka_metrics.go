@@ -0,0 +1,208 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/MH-Cognition/mhc-infra-observability/metrics"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// kafkaInstruments are the messaging metrics from the OTel messaging semantic conventions,
+// recorded when a span from the Kafka helpers ends, plus the consumer lag gauge.
+type kafkaInstruments struct {
+	operationDuration metric.Float64Histogram
+	processDuration   metric.Float64Histogram
+	sent              metric.Int64Counter
+	consumed          metric.Int64Counter
+	errors            metric.Int64Counter
+}
+
+var kafkaMetrics = metrics.NewLazy(func(m metric.Meter) kafkaInstruments {
+	operationDuration, _ := m.Float64Histogram("messaging.client.operation.duration",
+		metric.WithDescription("Duration of messaging operations initiated by a producer or consumer client."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	processDuration, _ := m.Float64Histogram("messaging.process.duration",
+		metric.WithDescription("Duration of processing operations."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	sent, _ := m.Int64Counter("messaging.client.sent.messages",
+		metric.WithDescription("Number of messages producers attempted to send."),
+		metric.WithUnit("{message}"),
+	)
+	consumed, _ := m.Int64Counter("messaging.client.consumed.messages",
+		metric.WithDescription("Number of messages delivered to consumers."),
+		metric.WithUnit("{message}"),
+	)
+	errors, _ := m.Int64Counter("messaging.client.operation.errors",
+		metric.WithDescription("Number of failed messaging operations."),
+		metric.WithUnit("{error}"),
+	)
+	// The gauge reports lagState on every collection; it needs no handle.
+	_, _ = m.Int64ObservableGauge("messaging.kafka.consumer.lag",
+		metric.WithDescription("Number of messages a consumer group is behind the end of a partition."),
+		metric.WithUnit("{message}"),
+		metric.WithInt64Callback(observeLag),
+	)
+	return kafkaInstruments{
+		operationDuration: operationDuration,
+		processDuration:   processDuration,
+		sent:              sent,
+		consumed:          consumed,
+		errors:            errors,
+	}
+})
+
+// kafkaSpan wraps the span returned by the Kafka helpers and records the messaging metrics when
+// it ends. It is also stored in the returned context, so errors recorded through the context
+// (e.g., observability.HandleError) are counted too.
+type kafkaSpan struct {
+	trace.Span
+	start    time.Time
+	op       string // kafkaOperationSend or kafkaOperationProcess
+	consumed int    // messages to count as consumed when the span ends
+
+	mu        sync.Mutex
+	attrs     []attribute.KeyValue // metric attributes; low cardinality only (no offset or key)
+	errorType string
+	ended     bool
+}
+
+// startKafkaSpan starts a span with opts and wraps it for metrics with the given attributes.
+func startKafkaSpan(ctx context.Context, name, op string, consumed int, attrs []attribute.KeyValue, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx, span := Tracer().Start(ctx, name, opts...)
+	ks := &kafkaSpan{
+		Span:     span,
+		start:    time.Now(),
+		op:       op,
+		consumed: consumed,
+		attrs:    append([]attribute.KeyValue{kafkaSystem, attribute.String("messaging.operation.name", op)}, attrs...),
+	}
+	kafkaMetrics.Get() // registers the lag gauge with the current meter
+	return trace.ContextWithSpan(ctx, ks), ks
+}
+
+// kafkaMetricAttrs returns the metric attributes for a message: topic, and partition and
+// consumer group when known.
+func kafkaMetricAttrs(topic, partition, group string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("messaging.destination.name", topic)}
+	if partition != "" {
+		attrs = append(attrs, attribute.String("messaging.destination.partition.id", partition))
+	}
+	if group != "" {
+		attrs = append(attrs, attribute.String("messaging.consumer.group.name", group))
+	}
+	return attrs
+}
+
+// SetStatus counts the operation as failed when code is codes.Error.
+func (s *kafkaSpan) SetStatus(code codes.Code, description string) {
+	if code == codes.Error {
+		s.fail("_OTHER")
+	}
+	s.Span.SetStatus(code, description)
+}
+
+// fail records errorType for the metrics, keeping a more specific type set earlier.
+func (s *kafkaSpan) fail(errorType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.errorType == "" || s.errorType == "_OTHER" {
+		s.errorType = errorType
+	}
+}
+
+// setPartition adds the partition a produced record was acknowledged on to the metric attributes.
+func (s *kafkaSpan) setPartition(partition int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attribute.String("messaging.destination.partition.id", strconv.Itoa(int(partition))))
+}
+
+// End records the metrics once and ends the span.
+func (s *kafkaSpan) End(opts ...trace.SpanEndOption) {
+	s.mu.Lock()
+	ended := s.ended
+	s.ended = true
+	attrs := s.attrs[:len(s.attrs):len(s.attrs)]
+	failed := s.errorType != ""
+	if failed {
+		attrs = append(attrs, attribute.String("error.type", s.errorType))
+	}
+	s.mu.Unlock()
+
+	if !ended {
+		s.record(attrs, failed)
+	}
+	s.Span.End(opts...)
+}
+
+func (s *kafkaSpan) record(attrs []attribute.KeyValue, failed bool) {
+	ctx := context.Background()
+	ins := kafkaMetrics.Get()
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	elapsed := time.Since(s.start).Seconds()
+
+	if s.op == kafkaOperationSend {
+		ins.operationDuration.Record(ctx, elapsed, set)
+		ins.sent.Add(ctx, 1, set)
+	} else {
+		ins.processDuration.Record(ctx, elapsed, set)
+		if s.consumed > 0 {
+			ins.consumed.Add(ctx, int64(s.consumed), set)
+		}
+	}
+	if failed {
+		ins.errors.Add(ctx, 1, set)
+	}
+}
+
+// lagKey identifies one partition of a topic read by a consumer group.
+type lagKey struct {
+	topic     string
+	partition int32
+	group     string
+}
+
+var (
+	lagMu    sync.RWMutex
+	lagState = map[lagKey]int64{}
+)
+
+// RecordKafkaConsumerLag sets the consumer lag of group on a topic partition: the partition's high
+// watermark minus the group's committed (or next) offset. The latest value of every partition is
+// reported by the messaging.kafka.consumer.lag gauge until ForgetKafkaConsumerLag removes it.
+func RecordKafkaConsumerLag(topic string, partition int32, group string, lag int64) {
+	kafkaMetrics.Get()
+	lagMu.Lock()
+	defer lagMu.Unlock()
+	lagState[lagKey{topic, partition, group}] = lag
+}
+
+// ForgetKafkaConsumerLag stops reporting the lag of a partition, e.g. after it was revoked from
+// this consumer in a rebalance.
+func ForgetKafkaConsumerLag(topic string, partition int32, group string) {
+	lagMu.Lock()
+	defer lagMu.Unlock()
+	delete(lagState, lagKey{topic, partition, group})
+}
+
+func observeLag(_ context.Context, o metric.Int64Observer) error {
+	lagMu.RLock()
+	defer lagMu.RUnlock()
+	for k, lag := range lagState {
+		attrs := append([]attribute.KeyValue{kafkaSystem},
+			kafkaMetricAttrs(k.topic, strconv.Itoa(int(k.partition)), k.group)...)
+		o.Observe(lag, metric.WithAttributeSet(attribute.NewSet(attrs...)))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MH-Cognition/mhc-infra-observability/observabilitytest"
+	"github.com/MH-Cognition/mhc-infra-observability/tracing"
+)
+
+func TestKafkaBatchMetricsRecordedOnce(t *testing.T) {
+	rec := observabilitytest.New(t)
+	messages := []tracing.KafkaConsumerMessage{
+		{Topic: "orders", Partition: 0, Offset: 1, ConsumerGroup: "billing"},
+		{Topic: "orders", Partition: 1, Offset: 7, ConsumerGroup: "billing"},
+	}
+
+	ctx, batch := tracing.StartKafkaBatchSpan(context.Background(), "orders", messages)
+	for _, msg := range messages {
+		_, span := tracing.StartKafkaBatchMessageSpan(ctx, msg)
+		span.End()
+	}
+	batch.End()
+
+	tests := []struct {
+		metric string
+		want   float64
+	}{
+		{metric: "messaging.process.duration", want: 1},
+		{metric: "messaging.client.consumed.messages", want: 2},
+	}
+	for _, tt := range tests {
+		if got, _ := rec.MetricValue(tt.metric); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.metric, got, tt.want)
+		}
+	}
+	if n := len(rec.Spans()); n != 3 {
+		t.Errorf("spans = %d, want 3", n)
+	}
+}
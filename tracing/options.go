@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// GrpcHealthMethodPrefix is the gRPC health service, never traced by the server interceptors.
+const GrpcHealthMethodPrefix = "/grpc.health.v1.Health/"
+
+// Prefixes from config.Config.HTTPIgnorePaths and GrpcIgnoreMethods, set by Init and applied by
+// every middleware and server interceptor at request time, so the order of Init and handler
+// construction does not matter.
+var (
+	ignoreMu          sync.RWMutex
+	cfgIgnoredPaths   []string
+	cfgIgnoredMethods []string
+)
+
+func setConfigIgnores(paths, methods []string) {
+	ignoreMu.Lock()
+	defer ignoreMu.Unlock()
+	cfgIgnoredPaths, cfgIgnoredMethods = paths, methods
+}
+
+func configIgnores() (paths, methods []string) {
+	ignoreMu.RLock()
+	defer ignoreMu.RUnlock()
+	return cfgIgnoredPaths, cfgIgnoredMethods
+}
+
+// Option configures the HTTP middleware and gRPC server interceptors.
+type Option func(*options)
+
+type options struct {
+	ignoredPaths   []string
+	httpFilter     func(*http.Request) bool
+	routeResolver  func(*http.Request) string
+	ignoredMethods []string
+	grpcFilter     func(fullMethod string) bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{ignoredMethods: []string{GrpcHealthMethodPrefix}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithIgnoredPaths skips tracing for HTTP requests whose URL path starts with any of the
+// prefixes (e.g., "/health", "/ready", "/metrics"). The request is passed through untraced.
+func WithIgnoredPaths(prefixes ...string) Option {
+	return func(o *options) {
+		o.ignoredPaths = append(o.ignoredPaths, prefixes...)
+	}
+}
+
+// WithHTTPFilter sets a predicate deciding per request whether it is traced;
+// returning false passes the request through untraced. Applied after WithIgnoredPaths.
+func WithHTTPFilter(f func(*http.Request) bool) Option {
+	return func(o *options) {
+		o.httpFilter = f
+	}
+}
+
+// WithRouteResolver sets a function returning the route template (e.g., "/orders/{id}") for a
+// request, used for the span name and http.route. It is called after the handler returns, so it
+// can read routing state set by third-party routers; returning "" falls back to the ServeMux
+// pattern (r.Pattern) and then to the path with ID-like segments collapsed.
+func WithRouteResolver(f func(*http.Request) string) Option {
+	return func(o *options) {
+		o.routeResolver = f
+	}
+}
+
+// WithIgnoredMethods skips tracing for gRPC calls whose full method ("/pkg.Service/Method")
+// starts with any of the prefixes. The health service is always ignored.
+func WithIgnoredMethods(prefixes ...string) Option {
+	return func(o *options) {
+		o.ignoredMethods = append(o.ignoredMethods, prefixes...)
+	}
+}
+
+// WithGrpcFilter sets a predicate deciding per gRPC full method whether the call is traced;
+// returning false passes the call through untraced. Applied after WithIgnoredMethods.
+func WithGrpcFilter(f func(fullMethod string) bool) Option {
+	return func(o *options) {
+		o.grpcFilter = f
+	}
+}
+
+// traceHTTP reports whether the request should get a span.
+func (o *options) traceHTTP(r *http.Request) bool {
+	paths, _ := configIgnores()
+	if hasAnyPrefix(r.URL.Path, o.ignoredPaths) || hasAnyPrefix(r.URL.Path, paths) {
+		return false
+	}
+	return o.httpFilter == nil || o.httpFilter(r)
+}
+
+// traceGrpc reports whether the gRPC call should get a span.
+func (o *options) traceGrpc(fullMethod string) bool {
+	_, methods := configIgnores()
+	if hasAnyPrefix(fullMethod, o.ignoredMethods) || hasAnyPrefix(fullMethod, methods) {
+		return false
+	}
+	return o.grpcFilter == nil || o.grpcFilter(fullMethod)
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
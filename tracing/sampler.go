@@ -0,0 +1,138 @@
+package tracing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewSampler builds the trace sampler from cfg.Sampler and cfg.SamplerArg. SamplingRules are
+// applied to root spans before the configured root sampler; with a parentbased_* sampler,
+// child spans still follow their parent's decision. Unknown sampler names fall back to
+// parentbased_always_on (the SDK default).
+func NewSampler(cfg *config.Config) sdktrace.Sampler {
+	var root sdktrace.Sampler
+	parentBased := true
+	switch cfg.Sampler {
+	case config.SamplerAlwaysOn:
+		root, parentBased = sdktrace.AlwaysSample(), false
+	case config.SamplerAlwaysOff:
+		root, parentBased = sdktrace.NeverSample(), false
+	case config.SamplerTraceIDRatio:
+		root, parentBased = sdktrace.TraceIDRatioBased(samplerRatio(cfg.SamplerArg)), false
+	case config.SamplerParentBasedAlwaysOff:
+		root = sdktrace.NeverSample()
+	case config.SamplerParentBasedTraceIDRatio:
+		root = sdktrace.TraceIDRatioBased(samplerRatio(cfg.SamplerArg))
+	default:
+		root = sdktrace.AlwaysSample()
+	}
+
+	if len(cfg.SamplingRules) > 0 {
+		root = NewRuleSampler(cfg.SamplingRules, root)
+	}
+	if parentBased {
+		return sdktrace.ParentBased(root)
+	}
+	return root
+}
+
+// samplerRatio parses OTEL_TRACES_SAMPLER_ARG; missing or invalid values mean 1 (sample all).
+func samplerRatio(arg string) float64 {
+	ratio, err := strconv.ParseFloat(arg, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 1
+	}
+	return ratio
+}
+
+// ruleSampler samples spans matching a SamplingRule at that rule's ratio and delegates the rest.
+type ruleSampler struct {
+	rules    []compiledRule
+	fallback sdktrace.Sampler
+}
+
+type compiledRule struct {
+	config.SamplingRule
+	sampler sdktrace.Sampler
+}
+
+// NewRuleSampler returns a sampler that applies the first matching rule's ratio and uses
+// fallback for spans no rule matches. Rules match on the attributes set at span start by
+// Middleware (http.target), the gRPC interceptors (rpc.service and rpc.method) and the Kafka span helpers
+// (messaging.destination.name). HTTP rules see the raw path: http.route is only set after the
+// handler returns, once the sampling decision has been made.
+func NewRuleSampler(rules []config.SamplingRule, fallback sdktrace.Sampler) sdktrace.Sampler {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, r := range rules {
+		compiled = append(compiled, compiledRule{
+			SamplingRule: r,
+			sampler:      sdktrace.TraceIDRatioBased(r.Ratio),
+		})
+	}
+	return &ruleSampler{rules: compiled, fallback: fallback}
+}
+
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, r := range s.rules {
+		if r.matches(p.Attributes) {
+			return r.sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *ruleSampler) Description() string {
+	parts := make([]string, 0, len(s.rules))
+	for _, r := range s.rules {
+		parts = append(parts, fmt.Sprintf("%s:%s=%g", r.Kind, r.Pattern, r.Ratio))
+	}
+	return fmt.Sprintf("RuleSampler{%s;fallback=%s}", strings.Join(parts, ","), s.fallback.Description())
+}
+
+// matches reports whether the span attributes carry a value for the rule's kind that matches Pattern.
+func (r compiledRule) matches(attrs []attribute.KeyValue) bool {
+	value, ok := r.value(attrs)
+	return ok && matchPattern(r.Pattern, value)
+}
+
+// value returns the span attribute value a rule of this kind matches against. gRPC rules match
+// the full method "/pkg.Service/Method", rebuilt from rpc.service and rpc.method.
+func (r compiledRule) value(attrs []attribute.KeyValue) (string, bool) {
+	switch r.Kind {
+	case config.RuleHTTPRoute:
+		return attrValue(attrs, "http.target")
+	case config.RuleGRPCMethod:
+		service, ok := attrValue(attrs, "rpc.service")
+		if !ok {
+			return "", false
+		}
+		method, _ := attrValue(attrs, "rpc.method")
+		return "/" + service + "/" + method, true
+	case config.RuleKafkaTopic:
+		return attrValue(attrs, "messaging.destination.name")
+	}
+	return "", false
+}
+
+func attrValue(attrs []attribute.KeyValue, key attribute.Key) (string, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value.AsString(), true
+		}
+	}
+	return "", false
+}
+
+// matchPattern matches value exactly, or by prefix when pattern ends with "*".
+func matchPattern(pattern, value string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(value, prefix)
+	}
+	return pattern == value
+}
@@ -0,0 +1,41 @@
+package tracing_test
+
+import (
+	"testing"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+	"github.com/MH-Cognition/mhc-infra-observability/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRuleSamplerHTTPMatchesRawPath(t *testing.T) {
+	rules := []config.SamplingRule{
+		{Kind: config.RuleHTTPRoute, Pattern: "/orders/{id}", Ratio: 0},
+		{Kind: config.RuleHTTPRoute, Pattern: "/health*", Ratio: 0},
+	}
+	sampler := tracing.NewRuleSampler(rules, sdktrace.AlwaysSample())
+
+	tests := []struct {
+		path string
+		want sdktrace.SamplingDecision
+	}{
+		{path: "/health", want: sdktrace.Drop},
+		{path: "/healthz", want: sdktrace.Drop},
+		{path: "/orders/42", want: sdktrace.RecordAndSample}, // route templates never match
+		{path: "/orders/{id}", want: sdktrace.Drop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res := sampler.ShouldSample(sdktrace.SamplingParameters{
+				TraceID:    trace.TraceID{1},
+				Attributes: []attribute.KeyValue{attribute.String("http.target", tt.path)},
+			})
+			if res.Decision != tt.want {
+				t.Errorf("decision for %q = %v, want %v", tt.path, res.Decision, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MH-Cognition/mhc-infra-observability/config"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newSpanExporter creates the span exporter selected by cfg.TracesExporter (OTLP when empty),
+// or nil for "none".
+func newSpanExporter(ctx context.Context, cfg *config.Config) (sdktrace.SpanExporter, error) {
+	switch cfg.TracesExporter {
+	case config.ExporterOTLP, "":
+		return newExporter(ctx, cfg.Traces)
+	case config.ExporterConsole:
+		return &consoleExporter{w: os.Stdout}, nil
+	case config.ExporterFile:
+		f, err := os.OpenFile(cfg.ExporterFilePath(config.SignalTraces), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		return &fileExporter{SpanExporter: exp, f: f}, nil
+	case config.ExporterNone:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported traces exporter %q", cfg.TracesExporter)
+}
+
+// consoleExporter prints each finished span as one human-readable line, for local development:
+//
+//	12:04:05.123 SPAN http.GET /orders/{id} 12.4ms trace=4bf9… span=00f0… parent=e457… http.status_code=200
+type consoleExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (e *consoleExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s SPAN %s %s trace=%s span=%s",
+			s.StartTime().Format("15:04:05.000"), s.Name(),
+			s.EndTime().Sub(s.StartTime()).Round(100*time.Microsecond),
+			s.SpanContext().TraceID(), s.SpanContext().SpanID())
+		if s.Parent().IsValid() {
+			fmt.Fprintf(&b, " parent=%s", s.Parent().SpanID())
+		}
+		if s.Status().Code == codes.Error {
+			fmt.Fprintf(&b, " ERROR %q", s.Status().Description)
+		}
+		for _, kv := range s.Attributes() {
+			fmt.Fprintf(&b, " %s=%s", kv.Key, kv.Value.Emit())
+		}
+		b.WriteByte('\n')
+		if _, err := io.WriteString(e.w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *consoleExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// fileExporter closes the JSON-lines file on shutdown.
+type fileExporter struct {
+	sdktrace.SpanExporter
+	f *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -12,15 +12,13 @@ import (
 	"sync"
 
 	"github.com/MH-Cognition/mhc-infra-observability/config"
+	"github.com/MH-Cognition/mhc-infra-observability/propagation"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
+	otelpropagation "go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
@@ -40,40 +38,111 @@ func Tracer() trace.Tracer {
 	return trace.NewNoopTracerProvider().Tracer(tracerName)
 }
 
-// Init initializes the OpenTelemetry TracerProvider with OTLP gRPC exporter.
+// InitOption customizes Init. Without options Init uses the exporter from cfg, the sampler from
+// cfg and the default propagator.
+type InitOption func(*initOptions)
+
+type initOptions struct {
+	sampler    sdktrace.Sampler
+	exporter   sdktrace.SpanExporter
+	processors []sdktrace.SpanProcessor
+	propagator otelpropagation.TextMapPropagator
+}
+
+// WithSampler replaces the sampler built from cfg (NewSampler).
+func WithSampler(s sdktrace.Sampler) InitOption {
+	return func(o *initOptions) {
+		o.sampler = s
+	}
+}
+
+// WithExporter replaces the exporter selected by cfg.TracesExporter; spans are still batched.
+func WithExporter(e sdktrace.SpanExporter) InitOption {
+	return func(o *initOptions) {
+		o.exporter = e
+	}
+}
+
+// WithSpanProcessor registers an additional span processor (e.g., to enrich or filter spans)
+// alongside the exporter's batch processor. May be given multiple times.
+func WithSpanProcessor(p sdktrace.SpanProcessor) InitOption {
+	return func(o *initOptions) {
+		o.processors = append(o.processors, p)
+	}
+}
+
+// WithPropagator replaces the propagator built from cfg.Propagators (OTEL_PROPAGATORS).
+func WithPropagator(p otelpropagation.TextMapPropagator) InitOption {
+	return func(o *initOptions) {
+		o.propagator = p
+	}
+}
+
+// Init initializes the OpenTelemetry TracerProvider with the exporter selected by cfg.TracesExporter:
+// OTLP (gRPC or HTTP/protobuf per cfg.Traces), console, file or none.
 // Order is strict: 1) create provider with resource 2) SetTracerProvider 3) then obtain tracer.
 // Uses the single Resource created by observability.NewResource (do not create resource here).
-// Registers the global TracerProvider and Propagator. Returns a shutdown function.
-func Init(ctx context.Context, res *resource.Resource, cfg *config.Config) (func(context.Context) error, error) {
-	conn, err := grpc.DialContext(ctx, cfg.OtelEndpoint,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("create OTLP gRPC connection: %w", err)
+// Registers the global TracerProvider and the Propagator built from cfg.Propagators, which is also
+// shared by every helper in the propagation package. cfg.HTTPIgnorePaths and cfg.GrpcIgnoreMethods
+// apply to all middleware and server interceptors. Returns a shutdown function;
+// after shutdown Tracer returns a noop tracer again.
+func Init(ctx context.Context, res *resource.Resource, cfg *config.Config, opts ...InitOption) (func(context.Context) error, error) {
+	o := &initOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	exporter := o.exporter
+	if exporter == nil {
+		var err error
+		exporter, err = newSpanExporter(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("create %s trace exporter: %w", cfg.TracesExporter, err)
+		}
 	}
 
-	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
-	if err != nil {
-		_ = conn.Close()
-		return nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+	sampler := o.sampler
+	if sampler == nil {
+		sampler = NewSampler(cfg)
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	tpOpts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
-	)
+		sdktrace.WithSampler(sampler),
+	}
+	switch {
+	case exporter == nil:
+		// OTEL_TRACES_EXPORTER=none: spans are still created, so trace context keeps propagating.
+	case o.exporter == nil && cfg.TracesExporter == config.ExporterConsole:
+		// Print spans as they end rather than in batches.
+		tpOpts = append(tpOpts, sdktrace.WithSyncer(exporter))
+	default:
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exporter))
+	}
+	for _, p := range o.processors {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(p))
+	}
+	tp := sdktrace.NewTracerProvider(tpOpts...)
+
+	prop := o.propagator
+	if prop == nil {
+		prop = propagation.FromNames(cfg.Propagators...)
+	}
 
 	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	))
+	otel.SetTextMapPropagator(prop)
+	propagation.SetPropagator(prop)
 
 	mu.Lock()
 	defaultTracer = tp.Tracer(tracerName)
 	mu.Unlock()
+	setConfigIgnores(cfg.HTTPIgnorePaths, cfg.GrpcIgnoreMethods)
 
 	shutdown := func(ctx context.Context) error {
+		mu.Lock()
+		defaultTracer = nil
+		mu.Unlock()
+		setConfigIgnores(nil, nil)
 		if err := tp.Shutdown(ctx); err != nil {
 			return fmt.Errorf("shutdown tracer provider: %w", err)
 		}